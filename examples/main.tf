@@ -121,10 +121,7 @@ resource "mongodb_db_index" "index_exemple_1" {
     field = "field_name_to_index2"
     value = "-1"
   }
-  keys {
-    field = "unique"
-    value = "true"
-  }
+  unique = true
 }
 
 
@@ -136,10 +133,7 @@ resource "mongodb_db_index" "ttl_index" {
     field = "field_name_to_index"
     value = "1"
   }
-  keys {
-    field = "expireAfterSeconds"
-    value = "86400"
-  }
+  expire_after_seconds = 86400
 }
 
 resource "mongodb_db_index" "partial_index" {
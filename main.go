@@ -21,7 +21,12 @@ func main() {
 		log.Fatal(err)
 	}
 
-	if err := tf6server.Serve(providerAddr, serverFactory); err != nil {
+	err = tf6server.Serve(providerAddr, serverFactory)
+	// Release the pooled connections held by the configured provider.
+	if closeErr := mongodb.CloseClients(ctx); closeErr != nil {
+		log.Printf("[WARN] closing mongodb clients: %s", closeErr)
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -32,7 +33,20 @@ type ClientConfig struct {
 	Proxy                   string
 	AuthMechanism           string
 	AuthMechanismProperties map[string]string
+	ConnectTimeout          time.Duration
+	ServerSelectionTimeout  time.Duration
+	MaxPoolSize             uint64
 }
+
+// Connection defaults applied when the provider leaves connect_timeout or
+// server_selection_timeout unset. They match the driver's own defaults except
+// the connect timeout, which keeps the provider's historical 10s. An unset
+// max_pool_size is left to the connection string or the driver.
+const (
+	defaultConnectTimeout         = 10 * time.Second
+	defaultServerSelectionTimeout = 30 * time.Second
+)
+
 type DbUser struct {
 	Name          string `json:"name"`
 	Password      string `json:"password"`
@@ -49,9 +63,11 @@ func (role Role) String() string {
 }
 
 type PrivilegeDto struct {
-	Db         string   `json:"db"`
-	Collection string   `json:"collection"`
-	Actions    []string `json:"actions"`
+	Db          string   `json:"db"`
+	Collection  string   `json:"collection"`
+	Cluster     bool     `json:"cluster"`
+	AnyResource bool     `json:"any_resource" mapstructure:"any_resource"`
+	Actions     []string `json:"actions"`
 }
 
 type Privilege struct {
@@ -80,8 +96,10 @@ type SingleResultGetRole struct {
 		} `json:"inheritedRoles"`
 		Privileges []struct {
 			Resource struct {
-				Db         string `json:"db"`
-				Collection string `json:"collection"`
+				Db          string `json:"db"`
+				Collection  string `json:"collection"`
+				Cluster     bool   `json:"cluster"`
+				AnyResource bool   `json:"anyResource"`
 			} `json:"resource"`
 			Actions []string `json:"actions"`
 		} `json:"privileges"`
@@ -135,6 +153,7 @@ func (c *ClientConfig) MongoClient() (*mongo.Client, error) {
 	}
 
 	opts := options.Client().ApplyURI(uri).SetDialer(dialer)
+	applyPoolOptions(c, opts)
 	if cred, ok := buildCredential(c); ok {
 		opts.SetAuth(cred)
 	}
@@ -152,6 +171,20 @@ func (c *ClientConfig) MongoClient() (*mongo.Client, error) {
 	return client, err
 }
 
+// applyPoolOptions sets the connection and pool tuning from the client config,
+// leaving the driver defaults in place for anything left at zero.
+func applyPoolOptions(c *ClientConfig, opts *options.ClientOptions) {
+	if c.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(c.ConnectTimeout)
+	}
+	if c.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(c.ServerSelectionTimeout)
+	}
+	if c.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(c.MaxPoolSize)
+	}
+}
+
 // buildCredential assembles the driver auth credential from the client config.
 // It returns ok=false when there is nothing to authenticate with (no mechanism
 // and no username/password pair), leaving the connection unauthenticated.
@@ -206,14 +239,38 @@ func (privilege Privilege) String() string {
 }
 
 type Resource struct {
-	Db         string `json:"db"`
-	Collection string `json:"collection"`
+	Db          string `json:"db"`
+	Collection  string `json:"collection"`
+	Cluster     bool   `json:"cluster,omitempty"`
+	AnyResource bool   `json:"anyResource,omitempty"`
 }
 
 func (resource Resource) String() string {
+	switch {
+	case resource.Cluster:
+		return " { cluster : true }"
+	case resource.AnyResource:
+		return " { anyResource : true }"
+	}
 	return fmt.Sprintf(" { db : %s , collection : %s }", resource.Db, resource.Collection)
 }
 
+// MarshalBSON emits exactly one resource form: { cluster: true },
+// { anyResource: true }, or { db, collection }. The server rejects documents
+// that mix them.
+func (resource Resource) MarshalBSON() ([]byte, error) {
+	switch {
+	case resource.Cluster:
+		return bson.Marshal(bson.D{{Key: "cluster", Value: true}})
+	case resource.AnyResource:
+		return bson.Marshal(bson.D{{Key: "anyResource", Value: true}})
+	}
+	return bson.Marshal(bson.D{
+		{Key: "db", Value: resource.Db},
+		{Key: "collection", Value: resource.Collection},
+	})
+}
+
 func createIAMUser(client *mongo.Client, userName string, roles []Role, authRestrictions bson.A) error {
 	rolesValue := roles
 	if rolesValue == nil {
@@ -264,6 +321,26 @@ func getUser(client *mongo.Client, username string, database string) (SingleResu
 	return decodedResult, nil
 }
 
+// grantRolesToUser adds roles to an existing user without touching the roles
+// it already holds.
+func grantRolesToUser(client *mongo.Client, userName string, database string, roles []Role) error {
+	result := client.Database(database).RunCommand(context.Background(), bson.D{
+		{Key: "grantRolesToUser", Value: userName},
+		{Key: "roles", Value: roles},
+	})
+	return result.Err()
+}
+
+// revokeRolesFromUser removes roles from an existing user, leaving any other
+// roles in place.
+func revokeRolesFromUser(client *mongo.Client, userName string, database string, roles []Role) error {
+	result := client.Database(database).RunCommand(context.Background(), bson.D{
+		{Key: "revokeRolesFromUser", Value: userName},
+		{Key: "roles", Value: roles},
+	})
+	return result.Err()
+}
+
 func getRole(client *mongo.Client, roleName string, database string) (SingleResultGetRole, error) {
 	result := client.Database(database).RunCommand(context.Background(), bson.D{{Key: "rolesInfo", Value: bson.D{
 		{Key: "role", Value: roleName},
@@ -277,57 +354,159 @@ func getRole(client *mongo.Client, roleName string, database string) (SingleResu
 	return decodedResult, nil
 }
 
+// grantPrivilegesToRole adds privileges to an existing role. The server merges
+// the actions into any privilege the role already has on the same resource.
+func grantPrivilegesToRole(client *mongo.Client, role string, database string, privilege []PrivilegeDto) error {
+	result := client.Database(database).RunCommand(context.Background(), bson.D{
+		{Key: "grantPrivilegesToRole", Value: role},
+		{Key: "privileges", Value: privilegesArray(privilege)},
+	})
+	return result.Err()
+}
+
+// revokePrivilegesFromRole removes the given actions from the role's privilege
+// on each resource, leaving its other actions and privileges in place.
+func revokePrivilegesFromRole(client *mongo.Client, role string, database string, privilege []PrivilegeDto) error {
+	result := client.Database(database).RunCommand(context.Background(), bson.D{
+		{Key: "revokePrivilegesFromRole", Value: role},
+		{Key: "privileges", Value: privilegesArray(privilege)},
+	})
+	return result.Err()
+}
+
 func createRole(client *mongo.Client, role string, roles []Role, privilege []PrivilegeDto, database string, authRestrictions bson.A) error {
+	cmd := bson.D{
+		{Key: "createRole", Value: role},
+		{Key: "privileges", Value: privilegesArray(privilege)},
+		{Key: "roles", Value: rolesArray(roles)},
+	}
+	if len(authRestrictions) > 0 {
+		cmd = append(cmd, bson.E{Key: "authenticationRestrictions", Value: authRestrictions})
+	}
+
+	if result := client.Database(database).RunCommand(context.Background(), cmd); result.Err() != nil {
+		return result.Err()
+	}
+	return nil
+}
+
+// updateRole replaces the privileges, inherited roles and authentication
+// restrictions of an existing role in place. Unlike dropRole + createRole, the
+// role stays granted to every user that holds it throughout the change.
+func updateRole(client *mongo.Client, role string, roles []Role, privilege []PrivilegeDto, database string, authRestrictions bson.A) error {
+	// Always send authenticationRestrictions on update (empty clears them).
+	if authRestrictions == nil {
+		authRestrictions = bson.A{}
+	}
+	cmd := bson.D{
+		{Key: "updateRole", Value: role},
+		{Key: "privileges", Value: privilegesArray(privilege)},
+		{Key: "roles", Value: rolesArray(roles)},
+		{Key: "authenticationRestrictions", Value: authRestrictions},
+	}
+
+	if result := client.Database(database).RunCommand(context.Background(), cmd); result.Err() != nil {
+		return result.Err()
+	}
+	return nil
+}
+
+// privilegesArray converts the configured privileges into the command's
+// privileges array, sending an empty array rather than null when there are none.
+func privilegesArray(privilege []PrivilegeDto) interface{} {
 	var privileges []Privilege
 	for _, element := range privilege {
 		var prv Privilege
 		prv.Resource = Resource{
-			Db:         element.Db,
-			Collection: element.Collection,
+			Db:          element.Db,
+			Collection:  element.Collection,
+			Cluster:     element.Cluster,
+			AnyResource: element.AnyResource,
 		}
 		prv.Actions = element.Actions
 		privileges = append(privileges, prv)
 	}
 
-	var privilegesValue interface{} = privileges
 	if len(privileges) == 0 {
-		privilegesValue = []bson.M{}
-	}
-	var rolesValue interface{} = roles
-	if len(roles) == 0 {
-		rolesValue = []bson.M{}
-	}
-	cmd := bson.D{
-		{Key: "createRole", Value: role},
-		{Key: "privileges", Value: privilegesValue},
-		{Key: "roles", Value: rolesValue},
-	}
-	if len(authRestrictions) > 0 {
-		cmd = append(cmd, bson.E{Key: "authenticationRestrictions", Value: authRestrictions})
+		return []bson.M{}
 	}
+	return privileges
+}
 
-	if result := client.Database(database).RunCommand(context.Background(), cmd); result.Err() != nil {
-		return result.Err()
+func rolesArray(roles []Role) interface{} {
+	if len(roles) == 0 {
+		return []bson.M{}
 	}
-	return nil
+	return roles
 }
 
+// MongoClientInit returns the client shared by every resource of a configured
+// provider. The first call connects and pings it; later calls reuse the same
+// pooled, concurrency-safe client. A failed connection is not cached, so the
+// next call retries. Clients are disconnected by CloseClients on shutdown.
 func MongoClientInit(conf *MongoDatabaseConfiguration) (*mongo.Client, error) {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+	if conf.client != nil {
+		return conf.client, nil
+	}
 
 	client, err := conf.Config.MongoClient()
 	if err != nil {
 		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), conf.MaxConnLifetime*time.Second)
+	pingTimeout := conf.Config.ServerSelectionTimeout
+	if pingTimeout <= 0 {
+		pingTimeout = defaultServerSelectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
 	defer cancel()
 	// client.Connect is deprecated, already connected by mongo.Connect above
 	err = client.Ping(ctx, nil)
 	if err != nil {
+		_ = client.Disconnect(context.Background())
 		return nil, err
 	}
+	conf.client = client
+	trackConfiguration(conf)
 	return client, nil
 }
 
+// openConfigurations records every configuration holding a live client so that
+// CloseClients can disconnect them when the provider process exits.
+var openConfigurations struct {
+	sync.Mutex
+	confs []*MongoDatabaseConfiguration
+}
+
+func trackConfiguration(conf *MongoDatabaseConfiguration) {
+	openConfigurations.Lock()
+	defer openConfigurations.Unlock()
+	openConfigurations.confs = append(openConfigurations.confs, conf)
+}
+
+// CloseClients disconnects every client opened through MongoClientInit. It is
+// called once the plugin server stops serving.
+func CloseClients(ctx context.Context) error {
+	openConfigurations.Lock()
+	confs := openConfigurations.confs
+	openConfigurations.confs = nil
+	openConfigurations.Unlock()
+
+	var errs []error
+	for _, conf := range confs {
+		conf.mu.Lock()
+		if conf.client != nil {
+			if err := conf.client.Disconnect(ctx); err != nil {
+				errs = append(errs, err)
+			}
+			conf.client = nil
+		}
+		conf.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
 func proxyDialer(c *ClientConfig) (options.ContextDialer, error) {
 	proxyFromEnv := proxy.FromEnvironment().(options.ContextDialer)
 	proxyFromProvider := c.Proxy
@@ -347,3 +526,21 @@ func proxyDialer(c *ClientConfig) (options.ContextDialer, error) {
 
 	return proxyFromEnv, nil
 }
+
+// serverVersionAtLeast reports whether the server runs MongoDB major.minor or
+// later, from buildInfo.
+func serverVersionAtLeast(ctx context.Context, client *mongo.Client, major, minor int32) (bool, error) {
+	var info struct {
+		VersionArray []int32 `bson:"versionArray"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&info); err != nil {
+		return false, fmt.Errorf("failed to run buildInfo : %s", err)
+	}
+	if len(info.VersionArray) < 2 {
+		return false, fmt.Errorf("unexpected buildInfo versionArray %v", info.VersionArray)
+	}
+	if info.VersionArray[0] != major {
+		return info.VersionArray[0] > major, nil
+	}
+	return info.VersionArray[1] >= minor, nil
+}
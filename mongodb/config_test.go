@@ -3,6 +3,10 @@ package mongodb
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // TestBuildCredential covers the auth credential assembled from the provider
@@ -111,3 +115,75 @@ func TestBuildCredential(t *testing.T) {
 		})
 	}
 }
+
+// TestApplyPoolOptions covers the connection/pool tuning carried from the
+// provider config onto the driver options, and that zero values leave the
+// driver defaults and connection string settings untouched.
+func TestApplyPoolOptions(t *testing.T) {
+	opts := options.Client()
+	applyPoolOptions(&ClientConfig{
+		ConnectTimeout:         5 * time.Second,
+		ServerSelectionTimeout: 15 * time.Second,
+		MaxPoolSize:            20,
+	}, opts)
+	if opts.ConnectTimeout == nil || *opts.ConnectTimeout != 5*time.Second {
+		t.Errorf("ConnectTimeout = %v, want 5s", opts.ConnectTimeout)
+	}
+	if opts.ServerSelectionTimeout == nil || *opts.ServerSelectionTimeout != 15*time.Second {
+		t.Errorf("ServerSelectionTimeout = %v, want 15s", opts.ServerSelectionTimeout)
+	}
+	if opts.MaxPoolSize == nil || *opts.MaxPoolSize != 20 {
+		t.Errorf("MaxPoolSize = %v, want 20", opts.MaxPoolSize)
+	}
+
+	unset := options.Client()
+	applyPoolOptions(&ClientConfig{}, unset)
+	if unset.ConnectTimeout != nil || unset.ServerSelectionTimeout != nil || unset.MaxPoolSize != nil {
+		t.Errorf("zero config should leave driver defaults, got %+v", unset)
+	}
+
+	fromURI := options.Client().ApplyURI("mongodb://localhost:27017/?maxPoolSize=7")
+	applyPoolOptions(&ClientConfig{}, fromURI)
+	if fromURI.MaxPoolSize == nil || *fromURI.MaxPoolSize != 7 {
+		t.Errorf("MaxPoolSize = %v, want the connection string's 7", fromURI.MaxPoolSize)
+	}
+}
+
+func TestResourceMarshalBSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource Resource
+		want     bson.D
+	}{
+		{
+			name:     "db and collection",
+			resource: Resource{Db: "app", Collection: "orders"},
+			want:     bson.D{{Key: "db", Value: "app"}, {Key: "collection", Value: "orders"}},
+		},
+		{
+			name:     "cluster ignores db and collection",
+			resource: Resource{Db: "app", Cluster: true},
+			want:     bson.D{{Key: "cluster", Value: true}},
+		},
+		{
+			name:     "anyResource",
+			resource: Resource{AnyResource: true},
+			want:     bson.D{{Key: "anyResource", Value: true}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := bson.Marshal(tc.resource)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var got bson.D
+			if err := bson.Unmarshal(raw, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
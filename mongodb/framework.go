@@ -3,13 +3,16 @@ package mongodb
 import (
 	"context"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
@@ -69,6 +72,21 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				ElementType: types.StringType,
 				Description: "Additional properties for the selected auth_mechanism, e.g. ENVIRONMENT and TOKEN_RESOURCE for MONGODB-OIDC or AWS_SESSION_TOKEN for MONGODB-AWS.",
 			},
+			"connect_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds to wait for a new connection to the mongodb server to be established",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"server_selection_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds to wait for a suitable mongodb server to become available for an operation",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_pool_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of connections in the pool shared by all resources",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
 		},
 	}
 }
@@ -89,6 +107,9 @@ type frameworkProviderModel struct {
 	Proxy              types.String `tfsdk:"proxy"`
 	AuthMechanism      types.String `tfsdk:"auth_mechanism"`
 	AuthMechanismProps types.Map    `tfsdk:"auth_mechanism_properties"`
+	ConnectTimeout     types.Int64  `tfsdk:"connect_timeout"`
+	ServerSelection    types.Int64  `tfsdk:"server_selection_timeout"`
+	MaxPoolSize        types.Int64  `tfsdk:"max_pool_size"`
 }
 
 func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -117,6 +138,13 @@ func (p *frameworkProvider) Configure(ctx context.Context, req provider.Configur
 		AuthMechanism:      strDefault(cfg.AuthMechanism, envDefault("MONGO_AUTH_MECHANISM", "")),
 	}
 
+	// Connection pool tuning, defaulted like the SDKv2 schema. The pool size
+	// is only set when configured, so a maxPoolSize in connection_string
+	// still applies.
+	clientConfig.ConnectTimeout = time.Duration(int64Default(cfg.ConnectTimeout, int64(defaultConnectTimeout/time.Second))) * time.Second
+	clientConfig.ServerSelectionTimeout = time.Duration(int64Default(cfg.ServerSelection, int64(defaultServerSelectionTimeout/time.Second))) * time.Second
+	clientConfig.MaxPoolSize = uint64(int64Default(cfg.MaxPoolSize, 0))
+
 	if !cfg.AuthMechanismProps.IsNull() && !cfg.AuthMechanismProps.IsUnknown() {
 		props := make(map[string]string, len(cfg.AuthMechanismProps.Elements()))
 		resp.Diagnostics.Append(cfg.AuthMechanismProps.ElementsAs(ctx, &props, false)...)
@@ -126,7 +154,7 @@ func (p *frameworkProvider) Configure(ctx context.Context, req provider.Configur
 		clientConfig.AuthMechanismProperties = props
 	}
 
-	mc := &MongoDatabaseConfiguration{Config: &clientConfig, MaxConnLifetime: 10}
+	mc := &MongoDatabaseConfiguration{Config: &clientConfig}
 	resp.ResourceData = mc
 	// List resources receive provider data from a separate field.
 	resp.ListResourceData = mc
@@ -138,6 +166,7 @@ func (p *frameworkProvider) ListResources(_ context.Context) []func() list.ListR
 		newDBRoleListResource,
 		newDBCollectionListResource,
 		newDBIndexListResource,
+		newDBViewListResource,
 	}
 }
 
@@ -147,11 +176,18 @@ func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resou
 		newDBRoleResource,
 		newDBCollectionResource,
 		newDBIndexResource,
+		newDBUserRoleGrantResource,
+		newDBRolePrivilegeResource,
+		newCollectionIndexesResource,
+		newDBViewResource,
+		newMaterializedViewResource,
 	}
 }
 
 func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		newIndexStatsDataSource,
+	}
 }
 
 func strDefault(v types.String, def string) string {
@@ -168,6 +204,13 @@ func boolDefault(v types.Bool, def bool) bool {
 	return v.ValueBool()
 }
 
+func int64Default(v types.Int64, def int64) int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	return v.ValueInt64()
+}
+
 func envDefault(key, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
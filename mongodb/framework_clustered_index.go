@@ -0,0 +1,84 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var clusteredIndexObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name": types.StringType,
+}}
+
+type clusteredIndexModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+// clusteredIndexBlock makes a collection clustered on _id (MongoDB 5.3+).
+// The server only accepts the key {_id: 1} with unique: true, so only the
+// index name is configurable. A collection cannot be clustered or
+// unclustered after creation.
+func clusteredIndexBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description:   "Makes this a clustered collection, whose documents are stored in _id order.",
+		Validators:    []validator.List{listvalidator.SizeAtMost(1)},
+		PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Optional:      true,
+					Computed:      true,
+					Description:   "Name of the clustered index. Defaults to \"_id_\".",
+					PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				},
+			},
+		},
+	}
+}
+
+// clusteredIndexFromList converts the configured block into the
+// clusteredIndex creation option, or nil when the collection is not
+// clustered.
+func clusteredIndexFromList(ctx context.Context, list types.List) (bson.D, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return nil, nil
+	}
+	var models []clusteredIndexModel
+	if diags := list.ElementsAs(ctx, &models, false); diags.HasError() {
+		return nil, diags
+	}
+	spec := bson.D{
+		{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}},
+		{Key: "unique", Value: true},
+	}
+	if name := models[0].Name; !name.IsNull() && !name.IsUnknown() {
+		spec = append(spec, bson.E{Key: "name", Value: name.ValueString()})
+	}
+	return spec, nil
+}
+
+// clusteredIndexList builds the state value from the clusteredIndex option
+// of a collection specification. Time-series collections report it as a
+// plain true, which is not a user-declared clustered index.
+func clusteredIndexList(spec bson.RawValue) types.List {
+	doc, ok := spec.DocumentOK()
+	if !ok {
+		return types.ListValueMust(clusteredIndexObjectType, []attr.Value{})
+	}
+	name := types.StringNull()
+	if n, ok := doc.Lookup("name").StringValueOK(); ok {
+		name = types.StringValue(n)
+	}
+	return types.ListValueMust(clusteredIndexObjectType, []attr.Value{
+		types.ObjectValueMust(clusteredIndexObjectType.AttrTypes, map[string]attr.Value{"name": name}),
+	})
+}
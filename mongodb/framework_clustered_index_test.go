@@ -0,0 +1,42 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestClusteredIndexList(t *testing.T) {
+	options := func(v interface{}) bson.RawValue {
+		raw, _ := bson.Marshal(bson.D{{Key: "clusteredIndex", Value: v}})
+		return bson.Raw(raw).Lookup("clusteredIndex")
+	}
+	clustered := func(name string) types.List {
+		return types.ListValueMust(clusteredIndexObjectType, []attr.Value{
+			types.ObjectValueMust(clusteredIndexObjectType.AttrTypes, map[string]attr.Value{"name": types.StringValue(name)}),
+		})
+	}
+	empty := types.ListValueMust(clusteredIndexObjectType, []attr.Value{})
+
+	cases := []struct {
+		name string
+		spec bson.RawValue
+		want types.List
+	}{
+		{"not clustered", bson.RawValue{}, empty},
+		{"time-series collection", options(true), empty},
+		{"clustered", options(bson.D{
+			{Key: "v", Value: int32(2)},
+			{Key: "key", Value: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Key: "name", Value: "by_id"},
+			{Key: "unique", Value: true},
+		}), clustered("by_id")},
+	}
+	for _, tc := range cases {
+		if got := clusteredIndexList(tc.spec); !got.Equal(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// collationSimpleLocale is the binary comparison collation. The server does
+// not report it back, so it reads as "no collation".
+const collationSimpleLocale = "simple"
+
+var collationObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"locale":           types.StringType,
+	"strength":         types.Int64Type,
+	"case_level":       types.BoolType,
+	"case_first":       types.StringType,
+	"numeric_ordering": types.BoolType,
+	"alternate":        types.StringType,
+	"max_variable":     types.StringType,
+	"backwards":        types.BoolType,
+}}
+
+type collationModel struct {
+	Locale          types.String `tfsdk:"locale"`
+	Strength        types.Int64  `tfsdk:"strength"`
+	CaseLevel       types.Bool   `tfsdk:"case_level"`
+	CaseFirst       types.String `tfsdk:"case_first"`
+	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
+	Alternate       types.String `tfsdk:"alternate"`
+	MaxVariable     types.String `tfsdk:"max_variable"`
+	Backwards       types.Bool   `tfsdk:"backwards"`
+}
+
+// collationDoc is the collation document as the server reports it, with every
+// field filled in from the locale defaults.
+type collationDoc struct {
+	Locale          string `bson:"locale"`
+	Strength        int32  `bson:"strength"`
+	CaseLevel       bool   `bson:"caseLevel"`
+	CaseFirst       string `bson:"caseFirst"`
+	NumericOrdering bool   `bson:"numericOrdering"`
+	Alternate       string `bson:"alternate"`
+	MaxVariable     string `bson:"maxVariable"`
+	Backwards       bool   `bson:"backwards"`
+}
+
+// collationBlock is shared by mongodb_db_index and mongodb_db_collection.
+// Everything but locale is Optional+Computed because the server fills unset
+// fields with the locale's defaults; a collation cannot be changed after
+// creation, so any change forces replacement.
+func collationBlock(description string) schema.ListNestedBlock {
+	attributes := collationAttributes()
+	for name, attribute := range attributes {
+		switch a := attribute.(type) {
+		case schema.Int64Attribute:
+			a.Computed = true
+			a.PlanModifiers = []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case schema.BoolAttribute:
+			a.Computed = true
+			a.PlanModifiers = []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case schema.StringAttribute:
+			if name == "locale" {
+				continue
+			}
+			a.Computed = true
+			a.PlanModifiers = []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		}
+	}
+	return schema.ListNestedBlock{
+		Description:   description,
+		Validators:    []validator.List{listvalidator.SizeAtMost(1)},
+		PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+		NestedObject:  schema.NestedBlockObject{Attributes: attributes},
+	}
+}
+
+// declaredCollationBlock is the collation block of an index entry in
+// mongodb_collection_indexes. Its fields are not computed: unset fields read
+// back as null (see declaredCollation) rather than as the locale's defaults.
+func declaredCollationBlock(description string) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description:  description,
+		Validators:   []validator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: schema.NestedBlockObject{Attributes: collationAttributes()},
+	}
+}
+
+func collationAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"locale": schema.StringAttribute{
+			Required:    true,
+			Description: "ICU locale, e.g. \"en\" or \"fr_CA\". \"simple\" selects binary comparison.",
+		},
+		"strength": schema.Int64Attribute{
+			Optional:    true,
+			Description: "Comparison level, 1 to 5. Strength 1 or 2 compares case-insensitively.",
+			Validators:  []validator.Int64{int64validator.Between(1, 5)},
+		},
+		"case_level": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Include case comparison at strength 1 or 2.",
+		},
+		"case_first": schema.StringAttribute{
+			Optional:    true,
+			Description: "Sort order of case differences at tertiary level: \"upper\", \"lower\" or \"off\".",
+			Validators:  []validator.String{stringvalidator.OneOf("upper", "lower", "off")},
+		},
+		"numeric_ordering": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Compare numeric strings as numbers.",
+		},
+		"alternate": schema.StringAttribute{
+			Optional:    true,
+			Description: "Whether whitespace and punctuation are base characters: \"non-ignorable\" or \"shifted\".",
+			Validators:  []validator.String{stringvalidator.OneOf("non-ignorable", "shifted")},
+		},
+		"max_variable": schema.StringAttribute{
+			Optional:    true,
+			Description: "Characters ignored when alternate is \"shifted\": \"punct\" or \"space\".",
+			Validators:  []validator.String{stringvalidator.OneOf("punct", "space")},
+		},
+		"backwards": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Sort strings with diacritics from the back of the string, as in French.",
+		},
+	}
+}
+
+// collationFromList converts the configured block into driver options, or nil
+// when no collation is set. Unknown computed fields are left for the server
+// to default.
+func collationFromList(ctx context.Context, list types.List) (*options.Collation, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return nil, nil
+	}
+	var models []collationModel
+	if diags := list.ElementsAs(ctx, &models, false); diags.HasError() {
+		return nil, diags
+	}
+	m := models[0]
+	return &options.Collation{
+		Locale:          m.Locale.ValueString(),
+		Strength:        int(m.Strength.ValueInt64()),
+		CaseLevel:       m.CaseLevel.ValueBool(),
+		CaseFirst:       m.CaseFirst.ValueString(),
+		NumericOrdering: m.NumericOrdering.ValueBool(),
+		Alternate:       m.Alternate.ValueString(),
+		MaxVariable:     m.MaxVariable.ValueString(),
+		Backwards:       m.Backwards.ValueBool(),
+	}, nil
+}
+
+// decodeCollation decodes a collation document from a listIndexes or
+// listCollections result. A nil document decodes to nil.
+func decodeCollation(raw bson.Raw) (*collationDoc, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	var doc collationDoc
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode collation : %s", err)
+	}
+	return &doc, nil
+}
+
+// collationList builds the state value for a collation read from the server.
+// The server never reports the simple collation, so a prior "simple" block is
+// kept as-is when nothing comes back.
+func collationList(doc *collationDoc, prior types.List) types.List {
+	if doc == nil {
+		if collationLocale(prior) == collationSimpleLocale {
+			return knownCollation(prior)
+		}
+		return types.ListValueMust(collationObjectType, []attr.Value{})
+	}
+	obj := types.ObjectValueMust(collationObjectType.AttrTypes, map[string]attr.Value{
+		"locale":           types.StringValue(doc.Locale),
+		"strength":         types.Int64Value(int64(doc.Strength)),
+		"case_level":       types.BoolValue(doc.CaseLevel),
+		"case_first":       types.StringValue(doc.CaseFirst),
+		"numeric_ordering": types.BoolValue(doc.NumericOrdering),
+		"alternate":        types.StringValue(doc.Alternate),
+		"max_variable":     types.StringValue(doc.MaxVariable),
+		"backwards":        types.BoolValue(doc.Backwards),
+	})
+	return types.ListValueMust(collationObjectType, []attr.Value{obj})
+}
+
+// knownCollation nulls out fields of a configured collation that were left
+// for the server to compute, so the list can be stored after apply.
+func knownCollation(list types.List) types.List {
+	obj := list.Elements()[0].(types.Object)
+	attrs := map[string]attr.Value{}
+	for name, value := range obj.Attributes() {
+		if value.IsUnknown() {
+			switch value.(type) {
+			case types.String:
+				value = types.StringNull()
+			case types.Int64:
+				value = types.Int64Null()
+			case types.Bool:
+				value = types.BoolNull()
+			}
+		}
+		attrs[name] = value
+	}
+	return types.ListValueMust(collationObjectType, []attr.Value{types.ObjectValueMust(collationObjectType.AttrTypes, attrs)})
+}
+
+// declaredCollation nulls the fields of a collation read from the server that
+// prior leaves unset, so the locale defaults the server fills in do not show
+// as drift. With no prior collation the full server value is kept.
+func declaredCollation(actual, prior types.List) types.List {
+	if len(actual.Elements()) == 0 || prior.IsNull() || prior.IsUnknown() || len(prior.Elements()) == 0 {
+		return actual
+	}
+	declared := prior.Elements()[0].(types.Object).Attributes()
+	attrs := map[string]attr.Value{}
+	for name, value := range actual.Elements()[0].(types.Object).Attributes() {
+		if declared[name].IsNull() {
+			switch value.(type) {
+			case types.String:
+				value = types.StringNull()
+			case types.Int64:
+				value = types.Int64Null()
+			case types.Bool:
+				value = types.BoolNull()
+			}
+		}
+		attrs[name] = value
+	}
+	return types.ListValueMust(collationObjectType, []attr.Value{types.ObjectValueMust(collationObjectType.AttrTypes, attrs)})
+}
+
+// collationLocale returns the locale of a single-element collation list, or
+// "" when no collation is set.
+func collationLocale(list types.List) string {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return ""
+	}
+	obj, ok := list.Elements()[0].(types.Object)
+	if !ok {
+		return ""
+	}
+	locale, ok := obj.Attributes()["locale"].(types.String)
+	if !ok {
+		return ""
+	}
+	return locale.ValueString()
+}
+
+// collectionCollation returns the default collation of a collection, or nil
+// when it uses simple binary comparison.
+func collectionCollation(client *mongo.Client, db, collectionName string) (*collationDoc, error) {
+	cursor, err := client.Database(db).ListCollections(context.Background(), bson.M{"name": collectionName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections : %s", err)
+	}
+	defer cursor.Close(context.Background())
+	if !cursor.Next(context.Background()) {
+		return nil, nil
+	}
+	var spec mongo.CollectionSpecification
+	if err := cursor.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode collection specification : %s", err)
+	}
+	raw, _ := spec.Options.Lookup("collation").DocumentOK()
+	return decodeCollation(raw)
+}
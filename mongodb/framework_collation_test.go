@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestCollationList(t *testing.T) {
+	simple := types.ListValueMust(collationObjectType, []attr.Value{
+		types.ObjectValueMust(collationObjectType.AttrTypes, map[string]attr.Value{
+			"locale":           types.StringValue("simple"),
+			"strength":         types.Int64Unknown(),
+			"case_level":       types.BoolUnknown(),
+			"case_first":       types.StringUnknown(),
+			"numeric_ordering": types.BoolUnknown(),
+			"alternate":        types.StringUnknown(),
+			"max_variable":     types.StringUnknown(),
+			"backwards":        types.BoolUnknown(),
+		}),
+	})
+
+	t.Run("server document round-trips into options", func(t *testing.T) {
+		raw, err := bson.Marshal(bson.D{
+			{Key: "locale", Value: "en"},
+			{Key: "caseLevel", Value: false},
+			{Key: "caseFirst", Value: "off"},
+			{Key: "strength", Value: int32(2)},
+			{Key: "numericOrdering", Value: false},
+			{Key: "alternate", Value: "non-ignorable"},
+			{Key: "maxVariable", Value: "punct"},
+			{Key: "normalization", Value: false},
+			{Key: "backwards", Value: false},
+			{Key: "version", Value: "57.1"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := decodeCollation(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list := collationList(doc, types.ListNull(collationObjectType))
+		if got := collationLocale(list); got != "en" {
+			t.Fatalf("locale: got %q, want %q", got, "en")
+		}
+		opts, diags := collationFromList(context.Background(), list)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if opts.Locale != "en" || opts.Strength != 2 || opts.CaseFirst != "off" || opts.MaxVariable != "punct" {
+			t.Errorf("unexpected options: %+v", opts)
+		}
+	})
+
+	t.Run("no collation reads as an empty block", func(t *testing.T) {
+		list := collationList(nil, types.ListNull(collationObjectType))
+		if list.IsNull() || len(list.Elements()) != 0 {
+			t.Errorf("got %v, want an empty list", list)
+		}
+	})
+
+	t.Run("declared simple collation is kept with unknowns nulled", func(t *testing.T) {
+		list := collationList(nil, simple)
+		if got := collationLocale(list); got != "simple" {
+			t.Fatalf("locale: got %q, want %q", got, "simple")
+		}
+		for name, value := range list.Elements()[0].(types.Object).Attributes() {
+			if value.IsUnknown() {
+				t.Errorf("attribute %q is still unknown", name)
+			}
+		}
+	})
+}
+
+func TestDeclaredCollation(t *testing.T) {
+	actual := collationList(&collationDoc{Locale: "en", Strength: 2, CaseFirst: "off", Alternate: "non-ignorable", MaxVariable: "punct"}, types.ListNull(collationObjectType))
+	declared := types.ListValueMust(collationObjectType, []attr.Value{
+		types.ObjectValueMust(collationObjectType.AttrTypes, map[string]attr.Value{
+			"locale":           types.StringValue("en"),
+			"strength":         types.Int64Value(2),
+			"case_level":       types.BoolNull(),
+			"case_first":       types.StringNull(),
+			"numeric_ordering": types.BoolNull(),
+			"alternate":        types.StringNull(),
+			"max_variable":     types.StringNull(),
+			"backwards":        types.BoolNull(),
+		}),
+	})
+
+	t.Run("undeclared fields are nulled", func(t *testing.T) {
+		got := declaredCollation(actual, declared)
+		if !got.Equal(declared) {
+			t.Errorf("got %v, want %v", got, declared)
+		}
+	})
+
+	t.Run("no prior collation keeps the server value", func(t *testing.T) {
+		got := declaredCollation(actual, types.ListNull(collationObjectType))
+		if !got.Equal(actual) {
+			t.Errorf("got %v, want %v", got, actual)
+		}
+	})
+
+	t.Run("no server collation stays empty", func(t *testing.T) {
+		empty := types.ListValueMust(collationObjectType, []attr.Value{})
+		if got := declaredCollation(empty, declared); !got.Equal(empty) {
+			t.Errorf("got %v, want an empty list", got)
+		}
+	})
+}
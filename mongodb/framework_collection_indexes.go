@@ -0,0 +1,755 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// idIndexName is the index the server builds on _id for every collection. It
+// cannot be dropped, so mongodb_collection_indexes never manages it.
+const idIndexName = "_id_"
+
+var collectionIndexObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":                      types.StringType,
+	"keys":                      types.ListType{ElemType: dbIndexKeyObjectType},
+	"partial_filter_expression": types.StringType,
+	"hidden":                    types.BoolType,
+	"unique":                    types.BoolType,
+	"sparse":                    types.BoolType,
+	"expire_after_seconds":      types.Int64Type,
+	"collation":                 types.ListType{ElemType: collationObjectType},
+	"weights":                   types.MapType{ElemType: types.Int64Type},
+	"default_language":          types.StringType,
+	"language_override":         types.StringType,
+	"text_index_version":        types.Int64Type,
+	"bits":                      types.Int64Type,
+	"min":                       types.Float64Type,
+	"max":                       types.Float64Type,
+	"sphere_index_version":      types.Int64Type,
+	"wildcard_projection":       types.StringType,
+}}
+
+// collectionIndexesResourceModel owns every index of one collection except
+// _id_ (unlike db_index, which manages a single index and ignores the rest).
+type collectionIndexesResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Db           types.String `tfsdk:"db"`
+	Collection   types.String `tfsdk:"collection"`
+	CommitQuorum types.String `tfsdk:"commit_quorum"`
+	Timeout      types.Int64  `tfsdk:"timeout"`
+	Index        types.List   `tfsdk:"index"`
+}
+
+// collectionIndexModel is one index block. The options are those of
+// dbIndexResourceModel, but none are computed: an option left unset reads
+// back as null, so indexes can be added, removed and reordered without
+// state from one list position leaking into another.
+type collectionIndexModel struct {
+	Name                    types.String  `tfsdk:"name"`
+	Keys                    types.List    `tfsdk:"keys"`
+	PartialFilterExpression types.String  `tfsdk:"partial_filter_expression"`
+	Hidden                  types.Bool    `tfsdk:"hidden"`
+	Unique                  types.Bool    `tfsdk:"unique"`
+	Sparse                  types.Bool    `tfsdk:"sparse"`
+	ExpireAfterSeconds      types.Int64   `tfsdk:"expire_after_seconds"`
+	Collation               types.List    `tfsdk:"collation"`
+	Weights                 types.Map     `tfsdk:"weights"`
+	DefaultLanguage         types.String  `tfsdk:"default_language"`
+	LanguageOverride        types.String  `tfsdk:"language_override"`
+	TextIndexVersion        types.Int64   `tfsdk:"text_index_version"`
+	Bits                    types.Int64   `tfsdk:"bits"`
+	Min                     types.Float64 `tfsdk:"min"`
+	Max                     types.Float64 `tfsdk:"max"`
+	SphereIndexVersion      types.Int64   `tfsdk:"sphere_index_version"`
+	WildcardProjection      types.String  `tfsdk:"wildcard_projection"`
+}
+
+type collectionIndexesResource struct {
+	config *MongoDatabaseConfiguration
+}
+
+func newCollectionIndexesResource() resource.Resource { return &collectionIndexesResource{} }
+
+var (
+	_ resource.Resource                   = &collectionIndexesResource{}
+	_ resource.ResourceWithConfigure      = &collectionIndexesResource{}
+	_ resource.ResourceWithImportState    = &collectionIndexesResource{}
+	_ resource.ResourceWithIdentity       = &collectionIndexesResource{}
+	_ resource.ResourceWithValidateConfig = &collectionIndexesResource{}
+)
+
+func (r *collectionIndexesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_indexes"
+}
+
+func (r *collectionIndexesResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
+
+func (r *collectionIndexesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete set of indexes of one collection. Indexes not declared here, other than _id_, are dropped.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"db": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"collection": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"commit_quorum": schema.StringAttribute{
+				Optional:    true,
+				Description: "Replica sets only. Data-bearing voting members that must finish the builds before the primary commits them: \"votingMembers\" (server default), \"majority\", a number, or a replica set tag name.",
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(30),
+				Description: "Seconds to wait for the createIndexes batch.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"index": schema.ListNestedBlock{
+				Description: "An index of the collection. Changing anything but hidden or expire_after_seconds drops and rebuilds the index.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Index name. Indexes are matched to the server's by name.",
+						},
+						"partial_filter_expression": schema.StringAttribute{
+							Optional:    true,
+							Description: "A JSON string representing the partialFilterExpression for a partial index.",
+						},
+						"hidden": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, the index is hidden from the query planner. Changed in place.",
+						},
+						"unique": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, the index rejects documents that duplicate an indexed value.",
+						},
+						"sparse": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, the index only references documents that contain the indexed field.",
+						},
+						"expire_after_seconds": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Makes this a TTL index. Changed in place; adding or removing it rebuilds the index.",
+							Validators:  []validator.Int64{int64validator.AtLeast(0)},
+						},
+						"weights": schema.MapAttribute{
+							ElementType: types.Int64Type,
+							Optional:    true,
+							Description: "Text index only. Relative weight (1 to 99999) of each text field.",
+							Validators:  []validator.Map{mapvalidator.ValueInt64sAre(int64validator.Between(1, 99999))},
+						},
+						"default_language": schema.StringAttribute{
+							Optional:    true,
+							Description: "Text index only. Language that determines stemming and stop words.",
+						},
+						"language_override": schema.StringAttribute{
+							Optional:    true,
+							Description: "Text index only. Document field that overrides the language per document.",
+						},
+						"text_index_version": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Text index only. Text index version.",
+						},
+						"bits": schema.Int64Attribute{
+							Optional:    true,
+							Description: "2d index only. Geohash precision in bits, 1 to 32.",
+							Validators:  []validator.Int64{int64validator.Between(1, 32)},
+						},
+						"min": schema.Float64Attribute{
+							Optional:    true,
+							Description: "2d index only. Lower bound (inclusive) for location values.",
+						},
+						"max": schema.Float64Attribute{
+							Optional:    true,
+							Description: "2d index only. Upper bound (exclusive) for location values.",
+						},
+						"sphere_index_version": schema.Int64Attribute{
+							Optional:    true,
+							Description: "2dsphere index only. 2dsphere index version.",
+						},
+						"wildcard_projection": schema.StringAttribute{
+							Optional:    true,
+							Description: "Wildcard index only. A JSON string with the fields to include or exclude.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"keys": schema.ListNestedBlock{
+							Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"field": schema.StringAttribute{Required: true},
+									"value": schema.StringAttribute{Required: true},
+								},
+							},
+						},
+						"collation": declaredCollationBlock("Collation for string comparisons in this index. Omit to inherit the collection's default collation."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *collectionIndexesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(*MongoDatabaseConfiguration)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *MongoDatabaseConfiguration, got %T", req.ProviderData))
+		return
+	}
+	r.config = config
+}
+
+func (r *collectionIndexesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan collectionIndexesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	if err := r.reconcile(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError("Could not apply the collection indexes", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(base64.StdEncoding.EncodeToString([]byte(plan.Db.ValueString() + "." + plan.Collection.ValueString())))
+	if err := r.readIndexesInto(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError("Error reading indexes after create", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: plan.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *collectionIndexesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state collectionIndexesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	if err := r.readIndexesInto(ctx, client, &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading collection indexes", err.Error())
+		return
+	}
+	if state.Timeout.IsNull() {
+		state.Timeout = types.Int64Value(30)
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *collectionIndexesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state collectionIndexesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	if err := r.reconcile(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError("Could not apply the collection indexes", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	if err := r.readIndexesInto(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError("Error reading indexes after update", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: plan.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete drops the indexes in state. The collection and its _id_ index are
+// left in place.
+func (r *collectionIndexesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state collectionIndexesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	db, collectionName, err := resourceDatabaseCollectionParseId(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse collection indexes ID", err.Error())
+		return
+	}
+	var indexes []collectionIndexModel
+	resp.Diagnostics.Append(state.Index.ElementsAs(ctx, &indexes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := listIndexSpecs(client, db, collectionName)
+	if err != nil {
+		if !isNotFound(err) {
+			resp.Diagnostics.AddError("Could not delete the collection indexes", err.Error())
+		}
+		return
+	}
+	present := map[string]bool{}
+	for _, result := range results {
+		if name, ok := result["name"].(string); ok {
+			present[name] = true
+		}
+	}
+	indexView := client.Database(db).Collection(collectionName).Indexes()
+	for _, index := range indexes {
+		name := index.Name.ValueString()
+		if !present[name] {
+			continue
+		}
+		if err := indexView.DropOne(ctx, name); err != nil {
+			resp.Diagnostics.AddError("Could not delete the index", fmt.Sprintf("index %q: %s", name, err))
+			return
+		}
+	}
+}
+
+func (r *collectionIndexesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ValidateConfig rejects duplicate index names and the _id_ index, which the
+// server creates and never lets go.
+func (r *collectionIndexesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var indexes types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("index"), &indexes)...)
+	if resp.Diagnostics.HasError() || indexes.IsNull() || indexes.IsUnknown() {
+		return
+	}
+	var models []collectionIndexModel
+	resp.Diagnostics.Append(indexes.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	seen := map[string]bool{}
+	for i, index := range models {
+		if index.Name.IsUnknown() || index.Name.IsNull() {
+			continue
+		}
+		name := index.Name.ValueString()
+		namePath := path.Root("index").AtListIndex(i).AtName("name")
+		if name == idIndexName {
+			resp.Diagnostics.AddAttributeError(namePath, "Reserved index name",
+				"The _id_ index is created by the server and cannot be managed; remove it from the configuration.")
+		}
+		if seen[name] {
+			resp.Diagnostics.AddAttributeError(namePath, "Duplicate index name",
+				fmt.Sprintf("Index %q is declared more than once.", name))
+		}
+		seen[name] = true
+	}
+}
+
+// reconcile makes the server's index set match the plan. Hidden and TTL
+// changes are applied with collMod. Indexes that are new by name are built
+// next, while every old index is still in place. Only then are unmanaged
+// indexes dropped, followed by the indexes whose definition changed, which
+// are rebuilt under the same name in one last createIndexes command. The
+// server cannot rename an index or hold two with the same name or key
+// pattern, so a changed index is missing for the length of that last build;
+// a new index whose key pattern matches one being dropped waits for it too.
+func (r *collectionIndexesResource) reconcile(ctx context.Context, client *mongo.Client, plan *collectionIndexesResourceModel) error {
+	db, collectionName := plan.Db.ValueString(), plan.Collection.ValueString()
+
+	var planned []collectionIndexModel
+	if diags := plan.Index.ElementsAs(ctx, &planned, false); diags.HasError() {
+		return fmt.Errorf("invalid index blocks")
+	}
+
+	// createIndexes creates the collection if it does not exist yet.
+	results, err := listIndexSpecs(client, db, collectionName)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	existing := map[string]bson.M{}
+	for _, result := range results {
+		if name, ok := result["name"].(string); ok {
+			existing[name] = result
+		}
+	}
+
+	wanted := map[string]bool{}
+	var changed, unmanaged []string
+	var collMods []collectionIndexModel
+	var additions, rebuilds []mongo.IndexModel
+	for _, index := range planned {
+		name := index.Name.ValueString()
+		wanted[name] = true
+		result, exists := existing[name]
+		if exists {
+			current, err := readCollectionIndex(client, db, collectionName, result, index)
+			if err != nil {
+				return err
+			}
+			if sameCollectionIndex(current, index) {
+				continue
+			}
+			inPlace := current
+			inPlace.Hidden = index.Hidden
+			if !current.ExpireAfterSeconds.IsNull() && !index.ExpireAfterSeconds.IsNull() {
+				inPlace.ExpireAfterSeconds = index.ExpireAfterSeconds
+			}
+			if sameCollectionIndex(inPlace, index) {
+				collMods = append(collMods, index)
+				continue
+			}
+			changed = append(changed, name)
+		}
+		spec := index.indexModel(db, collectionName)
+		model, err := indexModelFromPlan(ctx, &spec)
+		if err != nil {
+			return fmt.Errorf("index %q: %w", name, err)
+		}
+		if exists {
+			rebuilds = append(rebuilds, model)
+		} else {
+			additions = append(additions, model)
+		}
+	}
+	for _, result := range results {
+		name, _ := result["name"].(string)
+		if name != idIndexName && !wanted[name] {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+
+	// A new index with the key pattern of an index that is about to be
+	// dropped cannot be built next to it, so it waits for the rebuilds.
+	var dropping []bson.D
+	for _, name := range append(append([]string{}, changed...), unmanaged...) {
+		if keys, ok := existing[name]["key"].(bson.D); ok {
+			dropping = append(dropping, keys)
+		}
+	}
+	var firstBatch []mongo.IndexModel
+	for _, model := range additions {
+		if keys, _ := model.Keys.(bson.D); collidesWithAny(keys, dropping) {
+			rebuilds = append(rebuilds, model)
+		} else {
+			firstBatch = append(firstBatch, model)
+		}
+	}
+
+	indexView := client.Database(db).Collection(collectionName).Indexes()
+	for _, index := range collMods {
+		name := index.Name.ValueString()
+		if err := collModIndex(client, db, collectionName, name, bson.E{Key: "hidden", Value: index.Hidden.ValueBool()}); err != nil {
+			return fmt.Errorf("failed to update index %q hidden state : %w", name, err)
+		}
+		if !index.ExpireAfterSeconds.IsNull() {
+			if err := collModIndex(client, db, collectionName, name, bson.E{Key: "expireAfterSeconds", Value: index.ExpireAfterSeconds.ValueInt64()}); err != nil {
+				return fmt.Errorf("failed to update index %q expireAfterSeconds : %w", name, err)
+			}
+		}
+	}
+
+	timeout := int(plan.Timeout.ValueInt64())
+	cctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	createIndexes := func(models []mongo.IndexModel) error {
+		if len(models) == 0 {
+			return nil
+		}
+		if _, err := indexView.CreateMany(cctx, models, createIndexesOptions(plan.CommitQuorum.ValueString())); err != nil {
+			if errors.Is(cctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("indexes were not built within %d seconds (%w). The builds continue on the server; raise timeout for long builds", timeout, err)
+			}
+			return fmt.Errorf("failed to create indexes : %w", err)
+		}
+		return nil
+	}
+
+	if err := createIndexes(firstBatch); err != nil {
+		return err
+	}
+	for _, name := range append(unmanaged, changed...) {
+		tflog.Info(ctx, "dropping index", map[string]interface{}{"index": name})
+		if err := indexView.DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop index %q : %w", name, err)
+		}
+	}
+	if err := createIndexes(rebuilds); err != nil {
+		if len(changed) > 0 {
+			return fmt.Errorf("%w. Indexes %s were dropped to be rebuilt and are missing until the next apply", err, strings.Join(changed, ", "))
+		}
+		return err
+	}
+	return nil
+}
+
+// collidesWithAny reports whether keys names the same fields, in the same
+// order, as any of others. Key types are not compared: treating a near match
+// as a collision only delays the build until the old index is gone. A
+// collection holds one text index at most, so any two text indexes collide.
+func collidesWithAny(keys bson.D, others []bson.D) bool {
+	for _, other := range others {
+		if isTextKeys(keys) && isTextKeys(other) {
+			return true
+		}
+		if len(other) != len(keys) {
+			continue
+		}
+		same := true
+		for i := range keys {
+			if keys[i].Key != other[i].Key {
+				same = false
+				break
+			}
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// isTextKeys reports whether keys describe a text index, either as declared
+// or as the server lists it.
+func isTextKeys(keys bson.D) bool {
+	for _, key := range keys {
+		if key.Key == "_fts" || key.Value == "text" {
+			return true
+		}
+	}
+	return false
+}
+
+// readIndexesInto reads every index of the collection except _id_. Indexes
+// already in m keep their order; any others were added outside Terraform and
+// are appended, so they show up as drift to be dropped.
+func (r *collectionIndexesResource) readIndexesInto(ctx context.Context, client *mongo.Client, m *collectionIndexesResourceModel) error {
+	db, collectionName, err := resourceDatabaseCollectionParseId(m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	results, err := listIndexSpecs(client, db, collectionName)
+	if err != nil {
+		return err
+	}
+
+	var prior []collectionIndexModel
+	if !m.Index.IsNull() && !m.Index.IsUnknown() {
+		if diags := m.Index.ElementsAs(ctx, &prior, false); diags.HasError() {
+			return fmt.Errorf("invalid index blocks")
+		}
+	}
+	byName := map[string]bson.M{}
+	for _, result := range results {
+		if name, ok := result["name"].(string); ok {
+			byName[name] = result
+		}
+	}
+
+	indexes := []collectionIndexModel{}
+	read := map[string]bool{idIndexName: true}
+	for _, index := range prior {
+		name := index.Name.ValueString()
+		result, ok := byName[name]
+		if !ok || read[name] {
+			continue
+		}
+		current, err := readCollectionIndex(client, db, collectionName, result, index)
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, current)
+		read[name] = true
+	}
+	for _, result := range results {
+		name, _ := result["name"].(string)
+		if read[name] {
+			continue
+		}
+		current, err := readCollectionIndex(client, db, collectionName, result, unmanagedIndex())
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, current)
+	}
+
+	list, diags := types.ListValueFrom(ctx, collectionIndexObjectType, indexes)
+	if diags.HasError() {
+		return fmt.Errorf("building index list")
+	}
+	m.Db = types.StringValue(db)
+	m.Collection = types.StringValue(collectionName)
+	m.Index = list
+	return nil
+}
+
+// readCollectionIndex reads one listIndexes entry with readIndexSpecInto,
+// using prior as the declared index. Options prior leaves unset are nulled
+// when they hold the server default, so they do not show as drift.
+func readCollectionIndex(client *mongo.Client, db, collectionName string, result bson.M, prior collectionIndexModel) (collectionIndexModel, error) {
+	spec := prior.indexModel(db, collectionName)
+	if err := readIndexSpecInto(client, db, collectionName, result, &spec); err != nil {
+		return collectionIndexModel{}, err
+	}
+	name, _ := result["name"].(string)
+
+	index := collectionIndexModel{
+		Name:                    types.StringValue(name),
+		Keys:                    spec.Keys,
+		PartialFilterExpression: declaredString(spec.PartialFilterExpression, prior.PartialFilterExpression),
+		Hidden:                  declaredBool(spec.Hidden, prior.Hidden),
+		Unique:                  declaredBool(spec.Unique, prior.Unique),
+		Sparse:                  declaredBool(spec.Sparse, prior.Sparse),
+		ExpireAfterSeconds:      spec.ExpireAfterSeconds,
+		Collation:               declaredCollation(spec.Collation, prior.Collation),
+		Weights:                 spec.Weights,
+		DefaultLanguage:         types.StringNull(),
+		LanguageOverride:        types.StringNull(),
+		TextIndexVersion:        types.Int64Null(),
+		Bits:                    spec.Bits,
+		Min:                     spec.Min,
+		Max:                     spec.Max,
+		SphereIndexVersion:      types.Int64Null(),
+		WildcardProjection:      declaredString(spec.WildcardProjection, prior.WildcardProjection),
+	}
+	// The server fills these in on every text or 2dsphere index; only a
+	// declared value is compared.
+	if !prior.DefaultLanguage.IsNull() {
+		index.DefaultLanguage = spec.DefaultLanguage
+	}
+	if !prior.LanguageOverride.IsNull() {
+		index.LanguageOverride = spec.LanguageOverride
+	}
+	if !prior.TextIndexVersion.IsNull() {
+		index.TextIndexVersion = spec.TextIndexVersion
+	}
+	if !prior.SphereIndexVersion.IsNull() {
+		index.SphereIndexVersion = spec.SphereIndexVersion
+	}
+	return index, nil
+}
+
+// unmanagedIndex is the prior used to read an index that is not in state.
+func unmanagedIndex() collectionIndexModel {
+	return collectionIndexModel{
+		Keys:      types.ListNull(dbIndexKeyObjectType),
+		Collation: types.ListNull(collationObjectType),
+		Weights:   types.MapNull(types.Int64Type),
+	}
+}
+
+// indexModel converts an index block to the db_index model, so it can go
+// through indexModelFromPlan and readIndexSpecInto.
+func (m collectionIndexModel) indexModel(db, collectionName string) dbIndexResourceModel {
+	return dbIndexResourceModel{
+		Db:                      types.StringValue(db),
+		Collection:              types.StringValue(collectionName),
+		Name:                    m.Name,
+		Keys:                    m.Keys,
+		PartialFilterExpression: m.PartialFilterExpression,
+		Hidden:                  m.Hidden,
+		Unique:                  m.Unique,
+		Sparse:                  m.Sparse,
+		ExpireAfterSeconds:      m.ExpireAfterSeconds,
+		Collation:               m.Collation,
+		Weights:                 m.Weights,
+		DefaultLanguage:         m.DefaultLanguage,
+		LanguageOverride:        m.LanguageOverride,
+		TextIndexVersion:        m.TextIndexVersion,
+		Bits:                    m.Bits,
+		Min:                     m.Min,
+		Max:                     m.Max,
+		SphereIndexVersion:      m.SphereIndexVersion,
+		WildcardProjection:      m.WildcardProjection,
+	}
+}
+
+// sameCollectionIndex reports whether two index blocks describe the same
+// index.
+func sameCollectionIndex(a, b collectionIndexModel) bool {
+	objA, diagsA := types.ObjectValueFrom(context.Background(), collectionIndexObjectType.AttrTypes, a)
+	objB, diagsB := types.ObjectValueFrom(context.Background(), collectionIndexObjectType.AttrTypes, b)
+	if diagsA.HasError() || diagsB.HasError() {
+		return false
+	}
+	return objA.Equal(objB)
+}
+
+// declaredBool reads an unset flag that is false on the server as null.
+func declaredBool(actual, prior types.Bool) types.Bool {
+	if prior.IsNull() && !actual.ValueBool() {
+		return types.BoolNull()
+	}
+	return actual
+}
+
+// declaredString reads an unset option that is empty on the server as null.
+func declaredString(actual, prior types.String) types.String {
+	if prior.IsNull() && actual.ValueString() == "" {
+		return types.StringNull()
+	}
+	return actual
+}
@@ -0,0 +1,163 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// TestAccMongoDBCollectionIndexes_Basic builds two indexes in one batch,
+// checks that an index added by hand shows as drift and is dropped on the
+// next apply, and that hiding an index is applied in place.
+func TestAccMongoDBCollectionIndexes_Basic(t *testing.T) {
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+	collectionName := acctest.RandomWithPrefix("tf-acc-coll")
+	resourceName := "mongodb_collection_indexes.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionIndexes(dbName, collectionName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "index.0.name", "email_1"),
+					resource.TestCheckResourceAttr(resourceName, "index.0.unique", "true"),
+					resource.TestCheckResourceAttr(resourceName, "index.1.name", "created_at_ttl"),
+					resource.TestCheckResourceAttr(resourceName, "index.1.expire_after_seconds", "3600"),
+					testAccCheckMongoDBCollectionIndexNames(dbName, collectionName, "_id_", "email_1", "created_at_ttl"),
+				),
+			},
+			{
+				// An index created outside Terraform is read back as drift.
+				PreConfig: func() { testAccMongoDBCreateStrayIndex(t, dbName, collectionName) },
+				Config:    testAccMongoDBCollectionIndexes(dbName, collectionName, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index.#", "2"),
+					testAccCheckMongoDBCollectionIndexNames(dbName, collectionName, "_id_", "email_1", "created_at_ttl"),
+				),
+			},
+			{
+				Config: testAccMongoDBCollectionIndexes(dbName, collectionName, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index.0.hidden", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBCreateStrayIndex(t *testing.T, dbName, collectionName string) {
+	client, err := MongoClientInit(testAccMongoConfig())
+	if err != nil {
+		t.Fatalf("error connecting to database: %s", err)
+	}
+	_, err = client.Database(dbName).Collection(collectionName).Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "stray", Value: 1}},
+	})
+	if err != nil {
+		t.Fatalf("error creating index: %s", err)
+	}
+}
+
+func testAccCheckMongoDBCollectionIndexNames(dbName, collectionName string, want ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		names, err := client.Database(dbName).Collection(collectionName).Indexes().ListSpecifications(context.Background())
+		if err != nil {
+			return err
+		}
+		got := make([]string, 0, len(names))
+		for _, spec := range names {
+			got = append(got, spec.Name)
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("indexes: got %v, want %v", got, want)
+		}
+		return nil
+	}
+}
+
+func testAccMongoDBCollectionIndexes(dbName, collectionName string, hidden bool) string {
+	return fmt.Sprintf(`
+resource "mongodb_collection_indexes" "test" {
+  db         = %[1]q
+  collection = %[2]q
+
+  index {
+    name   = "email_1"
+    unique = true
+    hidden = %[3]t
+    keys {
+      field = "email"
+      value = "1"
+    }
+  }
+
+  index {
+    name                 = "created_at_ttl"
+    expire_after_seconds = 3600
+    keys {
+      field = "created_at"
+      value = "1"
+    }
+  }
+}
+`, dbName, collectionName, hidden)
+}
+
+func TestCollidesWithAny(t *testing.T) {
+	dropping := []bson.D{
+		{{Key: "email", Value: int32(1)}},
+		{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+	}
+	cases := []struct {
+		name string
+		keys bson.D
+		want bool
+	}{
+		{"same field", bson.D{{Key: "email", Value: 1}}, true},
+		{"same field other direction", bson.D{{Key: "email", Value: -1}}, true},
+		{"other field", bson.D{{Key: "name", Value: 1}}, false},
+		{"prefix only", bson.D{{Key: "email", Value: 1}, {Key: "name", Value: 1}}, false},
+		{"second text index", bson.D{{Key: "body", Value: "text"}}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := collidesWithAny(c.keys, dropping); got != c.want {
+				t.Errorf("collidesWithAny(%v) = %t, want %t", c.keys, got, c.want)
+			}
+		})
+	}
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 type dbCollectionResourceModel struct {
@@ -23,6 +30,19 @@ type dbCollectionResourceModel struct {
 	Name                         types.String `tfsdk:"name"`
 	DeletionProtection           types.Bool   `tfsdk:"deletion_protection"`
 	ChangeStreamPreAndPostImages types.Bool   `tfsdk:"change_stream_pre_and_post_images"`
+	Collation                    types.List   `tfsdk:"collation"`
+	Validator                    types.String `tfsdk:"validator"`
+	ValidationLevel              types.String `tfsdk:"validation_level"`
+	ValidationAction             types.String `tfsdk:"validation_action"`
+	Capped                       types.Bool   `tfsdk:"capped"`
+	Size                         types.Int64  `tfsdk:"size"`
+	Max                          types.Int64  `tfsdk:"max"`
+	Timeseries                   types.List   `tfsdk:"timeseries"`
+	ExpireAfterSeconds           types.Int64  `tfsdk:"expire_after_seconds"`
+	ClusteredIndex               types.List   `tfsdk:"clustered_index"`
+	StorageEngine                types.String `tfsdk:"storage_engine"`
+	PreviousDb                   types.String `tfsdk:"previous_db"`
+	PreviousName                 types.String `tfsdk:"previous_name"`
 }
 
 type dbCollectionResource struct {
@@ -32,10 +52,12 @@ type dbCollectionResource struct {
 func newDBCollectionResource() resource.Resource { return &dbCollectionResource{} }
 
 var (
-	_ resource.Resource                = &dbCollectionResource{}
-	_ resource.ResourceWithConfigure   = &dbCollectionResource{}
-	_ resource.ResourceWithImportState = &dbCollectionResource{}
-	_ resource.ResourceWithIdentity    = &dbCollectionResource{}
+	_ resource.Resource                   = &dbCollectionResource{}
+	_ resource.ResourceWithConfigure      = &dbCollectionResource{}
+	_ resource.ResourceWithImportState    = &dbCollectionResource{}
+	_ resource.ResourceWithIdentity       = &dbCollectionResource{}
+	_ resource.ResourceWithModifyPlan     = &dbCollectionResource{}
+	_ resource.ResourceWithValidateConfig = &dbCollectionResource{}
 )
 
 func (r *dbCollectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,14 +79,32 @@ func (r *dbCollectionResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
+			// Changing db or name forces a new collection unless
+			// previous_db/previous_name say where it is now; then Update
+			// renames it.
 			"db": schema.StringAttribute{
 				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				PlanModifiers: []planmodifier.String{collectionRenameRequiresReplace()},
 			},
 			"name": schema.StringAttribute{
 				Required:      true,
+				PlanModifiers: []planmodifier.String{collectionRenameRequiresReplace()},
+			},
+			"storage_engine": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString(""),
+				Description:   storageEngineDescription,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
+			"previous_db": schema.StringAttribute{
+				Optional:    true,
+				Description: "Database the collection is in before a move to db. Together with previous_name, makes a change of db or name rename the collection with renameCollection instead of replacing it.",
+			},
+			"previous_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the collection before a rename to name. Makes a change of name rename the collection with renameCollection instead of replacing it.",
+			},
 			"deletion_protection": schema.BoolAttribute{
 				Optional: true,
 				Computed: true,
@@ -75,6 +115,55 @@ func (r *dbCollectionResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed: true,
 				Default:  booldefault.StaticBool(false),
 			},
+			"validator": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Document validation rules as an Extended JSON query document, e.g. {\"$jsonSchema\": {...}}. Changed in place with collMod.",
+			},
+			"validation_level": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultValidationLevel),
+				Description: "Which writes the validator checks: \"strict\" (all inserts and updates), \"moderate\" (only documents that already pass) or \"off\".",
+				Validators:  []validator.String{stringvalidator.OneOf("off", "strict", "moderate")},
+			},
+			"validation_action": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultValidationAction),
+				Description: "What happens to an invalid write: \"error\" rejects it, \"warn\" only logs it, \"errorAndLog\" (MongoDB 8.1+) rejects and logs it.",
+				Validators:  []validator.String{stringvalidator.OneOf("error", "warn", "errorAndLog")},
+			},
+			"capped": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       booldefault.StaticBool(false),
+				Description:   "Create a capped collection, which overwrites its oldest documents once size or max is reached. Requires size.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			// size and max change in place on MongoDB 6.0+; ModifyPlan forces
+			// replacement on older servers.
+			"size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Capped collections only. Maximum size in bytes.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Capped collections only. Maximum number of documents.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time-series and clustered collections only. Delete documents this many seconds after their time field (time-series) or their _id date (clustered). Changed in place.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"collation":       collationBlock("Default collation for the collection, used by queries and indexes that do not specify their own."),
+			"timeseries":      timeseriesBlock(),
+			"clustered_index": clusteredIndexBlock(),
 		},
 	}
 }
@@ -108,7 +197,64 @@ func (r *dbCollectionResource) Create(ctx context.Context, req resource.CreateRe
 	collectionName := plan.Name.ValueString()
 	dbClient := client.Database(db)
 
-	if err := dbClient.CreateCollection(context.Background(), collectionName); err != nil {
+	createOptions := options.CreateCollection()
+	collation, diags := collationFromList(ctx, plan.Collation)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if collation != nil {
+		createOptions.SetCollation(collation)
+	}
+	validatorDoc, err := validatorDocument(plan.Validator.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("validator"), "Invalid validator", err.Error())
+		return
+	}
+	if validatorDoc != nil {
+		createOptions.SetValidator(validatorDoc)
+	}
+	if level := plan.ValidationLevel.ValueString(); level != defaultValidationLevel {
+		createOptions.SetValidationLevel(level)
+	}
+	if action := plan.ValidationAction.ValueString(); action != defaultValidationAction {
+		createOptions.SetValidationAction(action)
+	}
+	_, timeseriesOptions, diags := timeseriesFromList(ctx, plan.Timeseries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if timeseriesOptions != nil {
+		createOptions.SetTimeSeriesOptions(timeseriesOptions)
+	}
+	storageEngine, err := storageEngineDocument(plan.StorageEngine.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("storage_engine"), "Invalid storage_engine", err.Error())
+		return
+	}
+	if storageEngine != nil {
+		createOptions.SetStorageEngine(storageEngine)
+	}
+	clusteredIndex, diags := clusteredIndexFromList(ctx, plan.ClusteredIndex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if clusteredIndex != nil {
+		createOptions.SetClusteredIndex(clusteredIndex)
+	}
+	if !plan.ExpireAfterSeconds.IsNull() {
+		createOptions.SetExpireAfterSeconds(plan.ExpireAfterSeconds.ValueInt64())
+	}
+	if plan.Capped.ValueBool() {
+		createOptions.SetCapped(true).SetSizeInBytes(plan.Size.ValueInt64())
+		if !plan.Max.IsNull() {
+			createOptions.SetMaxDocuments(plan.Max.ValueInt64())
+		}
+	}
+
+	if err := dbClient.CreateCollection(context.Background(), collectionName, createOptions); err != nil {
 		resp.Diagnostics.AddError("Could not create the collection", err.Error())
 		return
 	}
@@ -146,6 +292,10 @@ func (r *dbCollectionResource) Read(ctx context.Context, req resource.ReadReques
 	// deletion_protection is a client-side flag, not stored in mongo; preserve it.
 	prevDeletionProtection := state.DeletionProtection
 	if err := r.readCollectionInto(client, state.ID.ValueString(), &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading collection", err.Error())
 		return
 	}
@@ -159,8 +309,9 @@ func (r *dbCollectionResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *dbCollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan dbCollectionResourceModel
+	var plan, prior dbCollectionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -171,11 +322,19 @@ func (r *dbCollectionResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	db, collectionName, err := resourceDatabaseCollectionParseId(plan.ID.ValueString())
+	db, collectionName, err := resourceDatabaseCollectionParseId(prior.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("ID mismatch", err.Error())
 		return
 	}
+	if collectionRenamed(plan, prior) {
+		if err := renameCollection(client, db, collectionName, plan.Db.ValueString(), plan.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Could not rename the collection", err.Error())
+			return
+		}
+		db, collectionName = plan.Db.ValueString(), plan.Name.ValueString()
+	}
+	id := base64.StdEncoding.EncodeToString([]byte(db + "." + collectionName))
 	dbClient := client.Database(db)
 
 	if diags := setChangeStreamPreAndPostImages(dbClient, collectionName, plan.ChangeStreamPreAndPostImages.ValueBool()); diags.HasError() {
@@ -183,8 +342,52 @@ func (r *dbCollectionResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	if !plan.Validator.Equal(prior.Validator) || !plan.ValidationLevel.Equal(prior.ValidationLevel) || !plan.ValidationAction.Equal(prior.ValidationAction) {
+		validatorDoc, err := validatorDocument(plan.Validator.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("validator"), "Invalid validator", err.Error())
+			return
+		}
+		if err := setCollectionValidation(dbClient, collectionName, validatorDoc, plan.ValidationLevel.ValueString(), plan.ValidationAction.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Could not update the collection validation", err.Error())
+			return
+		}
+	}
+
+	if !plan.Size.Equal(prior.Size) || !plan.Max.Equal(prior.Max) {
+		if err := resizeCappedCollection(dbClient, collectionName, plan.Size, plan.Max); err != nil {
+			resp.Diagnostics.AddError("Could not resize the capped collection", err.Error())
+			return
+		}
+	}
+
+	planTimeseries, _, diags := timeseriesFromList(ctx, plan.Timeseries)
+	resp.Diagnostics.Append(diags...)
+	priorTimeseries, _, diags := timeseriesFromList(ctx, prior.Timeseries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planTimeseries != nil && priorTimeseries != nil && !planTimeseries.Granularity.IsUnknown() && !planTimeseries.Granularity.Equal(priorTimeseries.Granularity) {
+		if err := collModCollection(dbClient, collectionName, bson.E{Key: "timeseries", Value: bson.D{{Key: "granularity", Value: planTimeseries.Granularity.ValueString()}}}); err != nil {
+			resp.Diagnostics.AddError("Could not change the time-series granularity", err.Error())
+			return
+		}
+	}
+	if !plan.ExpireAfterSeconds.Equal(prior.ExpireAfterSeconds) {
+		// collMod turns expiry off with the string "off".
+		var expireAfter interface{} = "off"
+		if !plan.ExpireAfterSeconds.IsNull() {
+			expireAfter = plan.ExpireAfterSeconds.ValueInt64()
+		}
+		if err := collModCollection(dbClient, collectionName, bson.E{Key: "expireAfterSeconds", Value: expireAfter}); err != nil {
+			resp.Diagnostics.AddError("Could not change expire_after_seconds", err.Error())
+			return
+		}
+	}
+
 	state := plan
-	if err := r.readCollectionInto(client, plan.ID.ValueString(), &state); err != nil {
+	if err := r.readCollectionInto(client, id, &state); err != nil {
 		resp.Diagnostics.AddError("Error reading collection after update", err.Error())
 		return
 	}
@@ -225,7 +428,9 @@ func (r *dbCollectionResource) ImportState(ctx context.Context, req resource.Imp
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-// readCollectionInto populates id, db, name and change_stream_pre_and_post_images
+// readCollectionInto populates id, db, name, change_stream_pre_and_post_images,
+// collation, the validation options, the capped settings, the
+// time-series options, the clustered index and the storage engine options
 // from the database. deletion_protection is a client-side flag preserved by the
 // caller (mirrors the SDKv2 read).
 func (r *dbCollectionResource) readCollectionInto(client *mongo.Client, id string, m *dbCollectionResourceModel) error {
@@ -240,7 +445,7 @@ func (r *dbCollectionResource) readCollectionInto(client *mongo.Client, id strin
 		return fmt.Errorf("failed to list collections : %s", err)
 	}
 	if !cursor.Next(context.Background()) {
-		return fmt.Errorf("collection does not exist")
+		return notFoundError{kind: "collection"}
 	}
 
 	var collectionSpec *mongo.CollectionSpecification
@@ -259,5 +464,344 @@ func (r *dbCollectionResource) readCollectionInto(client *mongo.Client, id strin
 	m.Db = types.StringValue(db)
 	m.Name = types.StringValue(collectionName)
 	m.ChangeStreamPreAndPostImages = types.BoolValue(changeStreamEnabled)
+
+	collationRaw, _ := collectionSpec.Options.Lookup("collation").DocumentOK()
+	collation, err := decodeCollation(collationRaw)
+	if err != nil {
+		return err
+	}
+	m.Collation = collationList(collation, m.Collation)
+
+	validatorRaw, _ := collectionSpec.Options.Lookup("validator").DocumentOK()
+	if m.Validator, err = validatorString(validatorRaw, m.Validator); err != nil {
+		return err
+	}
+	m.ValidationLevel = types.StringValue(defaultValidationLevel)
+	if level, ok := collectionSpec.Options.Lookup("validationLevel").StringValueOK(); ok {
+		m.ValidationLevel = types.StringValue(level)
+	}
+	m.ValidationAction = types.StringValue(defaultValidationAction)
+	if action, ok := collectionSpec.Options.Lookup("validationAction").StringValueOK(); ok {
+		m.ValidationAction = types.StringValue(action)
+	}
+
+	capped, _ := collectionSpec.Options.Lookup("capped").BooleanOK()
+	m.Capped = types.BoolValue(capped)
+	m.Size = types.Int64Null()
+	m.Max = types.Int64Null()
+	if capped {
+		if size, ok := collectionSpec.Options.Lookup("size").AsInt64OK(); ok {
+			m.Size = types.Int64Value(size)
+		}
+		// collMod cappedMax: 0 lifts the document limit.
+		if max, ok := collectionSpec.Options.Lookup("max").AsInt64OK(); ok && max > 0 {
+			m.Max = types.Int64Value(max)
+		}
+	}
+
+	timeseriesRaw, _ := collectionSpec.Options.Lookup("timeseries").DocumentOK()
+	m.Timeseries = timeseriesList(timeseriesRaw, m.Timeseries)
+	m.ClusteredIndex = clusteredIndexList(collectionSpec.Options.Lookup("clusteredIndex"))
+	storageEngineRaw, _ := collectionSpec.Options.Lookup("storageEngine").DocumentOK()
+	if m.StorageEngine, err = storageEngineString(storageEngineRaw, m.StorageEngine); err != nil {
+		return err
+	}
+	m.ExpireAfterSeconds = types.Int64Null()
+	if expireAfter, ok := collectionSpec.Options.Lookup("expireAfterSeconds").AsInt64OK(); ok {
+		m.ExpireAfterSeconds = types.Int64Value(expireAfter)
+	}
 	return nil
 }
+
+// collModCollection changes one option of a collection with collMod.
+func collModCollection(dbClient *mongo.Database, collectionName string, option bson.E) error {
+	return dbClient.RunCommand(context.Background(), bson.D{
+		{Key: "collMod", Value: collectionName},
+		option,
+	}).Err()
+}
+
+// ModifyPlan plans the new id of a renamed collection, and forces
+// replacement of a capped collection whose size or max changes on a server
+// too old to resize it in place.
+func (r *dbCollectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return // create or destroy
+	}
+	var plan, state dbCollectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if collectionRenamed(plan, state) {
+		id := base64.StdEncoding.EncodeToString([]byte(plan.Db.ValueString() + "." + plan.Name.ValueString()))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringValue(id))...)
+	}
+	if !plan.Capped.ValueBool() || !state.Capped.ValueBool() {
+		return
+	}
+	if plan.Size.Equal(state.Size) && plan.Max.Equal(state.Max) {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+	// collMod accepts cappedSize and cappedMax from MongoDB 6.0.
+	supported, err := serverVersionAtLeast(ctx, client, 6, 0)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not check whether the capped collection can be resized in place", err.Error())
+		return
+	}
+	if supported {
+		return
+	}
+	if !plan.Size.Equal(state.Size) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("size"))
+	}
+	if !plan.Max.Equal(state.Max) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("max"))
+	}
+}
+
+// ValidateConfig checks the combinations the server would reject: size and
+// max without capped, a capped collection without size, a capped
+// time-series or clustered collection, a clustered time-series collection,
+// inconsistent custom bucketing, and expire_after_seconds on a collection
+// that cannot expire documents.
+func (r *dbCollectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config dbCollectionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Capped.ValueBool() && config.Size.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("size"), "Missing size", "A capped collection requires size.")
+	}
+	if !config.Capped.IsUnknown() && !config.Capped.ValueBool() {
+		for _, attribute := range []struct {
+			name  string
+			value types.Int64
+		}{{"size", config.Size}, {"max", config.Max}} {
+			if !attribute.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Collection is not capped",
+					fmt.Sprintf("%s only applies to capped collections; set capped = true.", attribute.name))
+			}
+		}
+	}
+
+	if config.Timeseries.IsUnknown() || config.ClusteredIndex.IsUnknown() {
+		return
+	}
+	clustered := len(config.ClusteredIndex.Elements()) > 0
+	if clustered && config.Capped.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("capped"), "Capped clustered collection", "A clustered collection cannot be capped.")
+	}
+	timeseries, _, diags := timeseriesFromList(ctx, config.Timeseries)
+	resp.Diagnostics.Append(diags...)
+	if timeseries == nil {
+		if !clustered && !config.ExpireAfterSeconds.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("expire_after_seconds"), "Collection cannot expire documents",
+				"expire_after_seconds only applies to time-series and clustered collections.")
+		}
+		return
+	}
+	if clustered {
+		resp.Diagnostics.AddAttributeError(path.Root("clustered_index"), "Clustered time-series collection",
+			"A time-series collection is clustered by the server; remove the clustered_index block.")
+	}
+	if config.Capped.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("capped"), "Capped time-series collection", "A time-series collection cannot be capped.")
+	}
+	span, rounding := timeseries.BucketMaxSpanSeconds, timeseries.BucketRoundingSeconds
+	if span.IsUnknown() || rounding.IsUnknown() {
+		return
+	}
+	if span.IsNull() != rounding.IsNull() || span.ValueInt64() != rounding.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("timeseries"), "Invalid custom bucketing",
+			"bucket_max_span_seconds and bucket_rounding_seconds must be set together, to the same value.")
+	}
+	if !span.IsNull() && !timeseries.Granularity.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("timeseries"), "Invalid custom bucketing",
+			"granularity cannot be combined with bucket_max_span_seconds and bucket_rounding_seconds.")
+	}
+}
+
+// collectionRenamed reports whether plan moves the collection in state to a
+// new db or name, with previous_db and previous_name (each defaulting to the
+// planned value) naming where it is now.
+func collectionRenamed(plan, state dbCollectionResourceModel) bool {
+	if plan.PreviousDb.IsNull() && plan.PreviousName.IsNull() {
+		return false
+	}
+	if plan.Db.Equal(state.Db) && plan.Name.Equal(state.Name) {
+		return false
+	}
+	previousDb, previousName := plan.Db, plan.Name
+	if !plan.PreviousDb.IsNull() {
+		previousDb = plan.PreviousDb
+	}
+	if !plan.PreviousName.IsNull() {
+		previousName = plan.PreviousName
+	}
+	return previousDb.Equal(state.Db) && previousName.Equal(state.Name)
+}
+
+// collectionRenameRequiresReplace forces replacement when db or name changes,
+// unless the change is a rename declared with previous_db/previous_name.
+func collectionRenameRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		var plan, state dbCollectionResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.RequiresReplace = !collectionRenamed(plan, state)
+	}, "Changing db or name forces a new collection unless previous_db/previous_name name the current collection.",
+		"Changing `db` or `name` forces a new collection unless `previous_db`/`previous_name` name the current collection.")
+}
+
+// renameCollection renames db.collectionName to toDb.toName. Moving to
+// another database copies the documents and indexes.
+func renameCollection(client *mongo.Client, db, collectionName, toDb, toName string) error {
+	return client.Database("admin").RunCommand(context.Background(), bson.D{
+		{Key: "renameCollection", Value: db + "." + collectionName},
+		{Key: "to", Value: toDb + "." + toName},
+	}).Err()
+}
+
+// resizeCappedCollection changes the size and document limit of a capped
+// collection with collMod (MongoDB 6.0+). A null max lifts the limit.
+func resizeCappedCollection(dbClient *mongo.Database, collectionName string, size, max types.Int64) error {
+	return dbClient.RunCommand(context.Background(), bson.D{
+		{Key: "collMod", Value: collectionName},
+		{Key: "cappedSize", Value: size.ValueInt64()},
+		{Key: "cappedMax", Value: max.ValueInt64()},
+	}).Err()
+}
+
+// The server's validation settings when a collection does not set them.
+const (
+	defaultValidationLevel  = "strict"
+	defaultValidationAction = "error"
+)
+
+// validatorDocument parses the validator attribute; "" means no validator.
+func validatorDocument(validatorJSON string) (bson.D, error) {
+	if validatorJSON == "" {
+		return nil, nil
+	}
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON([]byte(validatorJSON), false, &doc); err != nil {
+		return nil, fmt.Errorf("validator is not a valid Extended JSON document : %s", err)
+	}
+	return doc, nil
+}
+
+// validatorString builds the state value from the validator the server
+// reports, keeping prior when it is the same document spelled differently
+// (whitespace, {"$numberLong": ...}). No validator reads as "".
+func validatorString(raw bson.Raw, prior types.String) (types.String, error) {
+	if elements, _ := raw.Elements(); len(elements) == 0 {
+		return types.StringValue(""), nil
+	}
+	validatorJSON, err := bson.MarshalExtJSON(raw, false, false)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("failed to read collection validator : %w", err)
+	}
+	if describesDocument(prior.ValueString(), raw) {
+		return prior, nil
+	}
+	return types.StringValue(string(validatorJSON)), nil
+}
+
+// setCollectionValidation replaces the validator and its level and action
+// with collMod. A nil validator removes it.
+func setCollectionValidation(dbClient *mongo.Database, collectionName string, validatorDoc bson.D, level, action string) error {
+	if validatorDoc == nil {
+		validatorDoc = bson.D{}
+	}
+	return dbClient.RunCommand(context.Background(), bson.D{
+		{Key: "collMod", Value: collectionName},
+		{Key: "validator", Value: validatorDoc},
+		{Key: "validationLevel", Value: level},
+		{Key: "validationAction", Value: action},
+	}).Err()
+}
+
+// storageEngineDescription is shared by mongodb_db_collection and
+// mongodb_db_index.
+const storageEngineDescription = "Storage engine options applied at creation, as an Extended JSON document such as {\"wiredTiger\": {\"configString\": \"block_compressor=zstd\"}}, or just the WiredTiger configString, e.g. \"block_compressor=zstd\". Changing it forces replacement."
+
+// storageEngineDocument parses the storage_engine attribute: an Extended
+// JSON document, or a bare WiredTiger configString. "" means none.
+func storageEngineDocument(storageEngine string) (bson.D, error) {
+	if storageEngine == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(strings.TrimSpace(storageEngine), "{") {
+		return bson.D{{Key: "wiredTiger", Value: bson.D{{Key: "configString", Value: storageEngine}}}}, nil
+	}
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON([]byte(storageEngine), false, &doc); err != nil {
+		return nil, fmt.Errorf("storage_engine is neither a configString nor a valid Extended JSON document : %s", err)
+	}
+	return doc, nil
+}
+
+// storageEngineString builds the state value from the storageEngine option
+// the server reports, keeping prior when it describes the same options in
+// another form (e.g. a bare configString).
+func storageEngineString(raw bson.Raw, prior types.String) (types.String, error) {
+	if elements, _ := raw.Elements(); len(elements) == 0 {
+		return types.StringValue(""), nil
+	}
+	actual, err := bson.MarshalExtJSON(raw, false, false)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("failed to read storage engine options : %s", err)
+	}
+	if priorDoc, err := storageEngineDocument(prior.ValueString()); err == nil && priorDoc != nil {
+		if priorJSON, err := bson.MarshalExtJSON(priorDoc, false, false); err == nil && sameExtJSONDocument(string(priorJSON), string(actual)) {
+			return prior, nil
+		}
+	}
+	return types.StringValue(string(actual)), nil
+}
+
+// describesDocument reports whether the Extended JSON string s decodes to
+// raw, with BSON types compared exactly. Going through raw's relaxed
+// spelling instead would lose them: an int64 1 prints as 1 and parses back
+// as an int32.
+func describesDocument(s string, raw bson.Raw) bool {
+	if s == "" {
+		return false
+	}
+	var doc bson.D
+	if bson.UnmarshalExtJSON([]byte(s), false, &doc) != nil {
+		return false
+	}
+	canonicalS, errS := bson.MarshalExtJSON(doc, true, false)
+	canonicalRaw, errRaw := bson.MarshalExtJSON(raw, true, false)
+	return errS == nil && errRaw == nil && string(canonicalS) == string(canonicalRaw)
+}
+
+// sameExtJSONDocument reports whether two Extended JSON strings decode to the
+// same document, ignoring whitespace and how values are spelled (1 and
+// {"$numberInt": "1"}). Field order counts.
+func sameExtJSONDocument(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	var docA, docB bson.D
+	if bson.UnmarshalExtJSON([]byte(a), false, &docA) != nil || bson.UnmarshalExtJSON([]byte(b), false, &docB) != nil {
+		return false
+	}
+	canonicalA, errA := bson.MarshalExtJSON(docA, true, false)
+	canonicalB, errB := bson.MarshalExtJSON(docB, true, false)
+	return errA == nil && errB == nil && string(canonicalA) == string(canonicalB)
+}
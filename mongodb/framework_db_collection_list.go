@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
@@ -30,7 +31,7 @@ func (r *dbCollectionListResource) Metadata(_ context.Context, req resource.Meta
 
 func (r *dbCollectionListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
 	resp.Schema = listschema.Schema{
-		MarkdownDescription: "Lists all MongoDB collections across databases. Use with `terraform query` (Terraform 1.14 and later).",
+		MarkdownDescription: "Lists all MongoDB collections and views across databases. Views are shown as `name (view)`. Use with `terraform query` (Terraform 1.14 and later).",
 	}
 }
 
@@ -65,14 +66,26 @@ func (r *dbCollectionListResource) List(ctx context.Context, req list.ListReques
 
 	stream.Results = func(push func(list.ListResult) bool) {
 		for _, dbName := range dbNames {
-			collNames, err := client.Database(dbName).ListCollectionNames(ctx, bson.D{})
+			specs, err := client.Database(dbName).ListCollectionSpecifications(ctx, bson.D{})
 			if err != nil {
 				continue // skip databases we can't list collections for
 			}
-			for _, coll := range collNames {
+			for _, spec := range specs {
+				coll := spec.Name
+				// Time-series collections store their data in an internal
+				// system.buckets.<name> collection; only the view is managed.
+				// system.views holds the definitions of the views.
+				if strings.HasPrefix(coll, timeseriesBucketsPrefix) || coll == "system.views" {
+					continue
+				}
 				id := base64.StdEncoding.EncodeToString([]byte(dbName + "." + coll))
 				result := req.NewListResult(ctx)
 				result.DisplayName = coll
+				if spec.Type == "view" {
+					// Views are managed with mongodb_db_view, which has its
+					// own list resource.
+					result.DisplayName = coll + " (view)"
+				}
 				result.Diagnostics.Append(result.Identity.Set(ctx, dbUserIdentityModel{ID: types.StringValue(id)})...)
 				if !push(result) {
 					return
@@ -3,23 +3,32 @@ package mongodb
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -31,14 +40,31 @@ var dbIndexKeyObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
 }}
 
 type dbIndexResourceModel struct {
-	ID                      types.String `tfsdk:"id"`
-	Db                      types.String `tfsdk:"db"`
-	Collection              types.String `tfsdk:"collection"`
-	Keys                    types.List   `tfsdk:"keys"`
-	Name                    types.String `tfsdk:"name"`
-	PartialFilterExpression types.String `tfsdk:"partial_filter_expression"`
-	Hidden                  types.Bool   `tfsdk:"hidden"`
-	Timeout                 types.Int64  `tfsdk:"timeout"`
+	ID                      types.String  `tfsdk:"id"`
+	Db                      types.String  `tfsdk:"db"`
+	Collection              types.String  `tfsdk:"collection"`
+	Keys                    types.List    `tfsdk:"keys"`
+	Name                    types.String  `tfsdk:"name"`
+	PartialFilterExpression types.String  `tfsdk:"partial_filter_expression"`
+	Hidden                  types.Bool    `tfsdk:"hidden"`
+	Unique                  types.Bool    `tfsdk:"unique"`
+	Sparse                  types.Bool    `tfsdk:"sparse"`
+	ExpireAfterSeconds      types.Int64   `tfsdk:"expire_after_seconds"`
+	Collation               types.List    `tfsdk:"collation"`
+	Weights                 types.Map     `tfsdk:"weights"`
+	DefaultLanguage         types.String  `tfsdk:"default_language"`
+	LanguageOverride        types.String  `tfsdk:"language_override"`
+	TextIndexVersion        types.Int64   `tfsdk:"text_index_version"`
+	Bits                    types.Int64   `tfsdk:"bits"`
+	Min                     types.Float64 `tfsdk:"min"`
+	Max                     types.Float64 `tfsdk:"max"`
+	SphereIndexVersion      types.Int64   `tfsdk:"sphere_index_version"`
+	WildcardProjection      types.String  `tfsdk:"wildcard_projection"`
+	StorageEngine           types.String  `tfsdk:"storage_engine"`
+	CommitQuorum            types.String  `tfsdk:"commit_quorum"`
+	WaitForBuild            types.Bool    `tfsdk:"wait_for_build"`
+	AdoptExisting           types.Bool    `tfsdk:"adopt_existing"`
+	Timeout                 types.Int64   `tfsdk:"timeout"`
 }
 
 type dbIndexKeyModel struct {
@@ -53,10 +79,12 @@ type dbIndexResource struct {
 func newDBIndexResource() resource.Resource { return &dbIndexResource{} }
 
 var (
-	_ resource.Resource                = &dbIndexResource{}
-	_ resource.ResourceWithConfigure   = &dbIndexResource{}
-	_ resource.ResourceWithImportState = &dbIndexResource{}
-	_ resource.ResourceWithIdentity    = &dbIndexResource{}
+	_ resource.Resource                   = &dbIndexResource{}
+	_ resource.ResourceWithConfigure      = &dbIndexResource{}
+	_ resource.ResourceWithImportState    = &dbIndexResource{}
+	_ resource.ResourceWithIdentity       = &dbIndexResource{}
+	_ resource.ResourceWithUpgradeState   = &dbIndexResource{}
+	_ resource.ResourceWithValidateConfig = &dbIndexResource{}
 )
 
 func (r *dbIndexResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,6 +101,9 @@ func (r *dbIndexResource) IdentitySchema(_ context.Context, _ resource.IdentityS
 
 func (r *dbIndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 moved unique/sparse/expireAfterSeconds out of keys; see
+		// UpgradeState.
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:      true,
@@ -110,10 +141,134 @@ func (r *dbIndexResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Default:     booldefault.StaticBool(false),
 				Description: "If true, the index is hidden from the query planner (MongoDB 4.4+). Can be toggled without recreating the index.",
 			},
+			"unique": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, the index rejects documents that duplicate an indexed value. Turning it on converts the index in place (MongoDB 6.0+); turning it off forces a new index.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+					}, "Making a unique index non-unique forces a new index.", "Making a unique index non-unique forces a new index."),
+				},
+			},
+			"sparse": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       booldefault.StaticBool(false),
+				Description:   "If true, the index only references documents that contain the indexed field.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			// expire_after_seconds changes in place via collMod; only removing
+			// the TTL needs a rebuild, since collMod cannot unset it.
+			"expire_after_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Makes this a TTL index: documents expire this many seconds after the time in the indexed date field. Changed in place; removing it forces a new index.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+					}, "Removing expire_after_seconds forces a new index.", "Removing `expire_after_seconds` forces a new index."),
+				},
+			},
+			"weights": schema.MapAttribute{
+				ElementType:   types.Int64Type,
+				Optional:      true,
+				Description:   "Text index only. Relative weight (1 to 99999) of each text field; fields not listed weigh 1.",
+				Validators:    []validator.Map{mapvalidator.ValueInt64sAre(int64validator.Between(1, 99999))},
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"default_language": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Text index only. Language that determines stemming and stop words. The server defaults to \"english\".",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"language_override": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Text index only. Document field that overrides the language per document. The server defaults to \"language\".",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"text_index_version": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Text index only. Text index version; the server defaults to the newest it supports.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"bits": schema.Int64Attribute{
+				Optional:      true,
+				Description:   "2d index only. Geohash precision in bits, 1 to 32 (server default 26).",
+				Validators:    []validator.Int64{int64validator.Between(1, 32)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"min": schema.Float64Attribute{
+				Optional:      true,
+				Description:   "2d index only. Lower bound (inclusive) for location values (server default -180).",
+				PlanModifiers: []planmodifier.Float64{float64planmodifier.RequiresReplace()},
+			},
+			"max": schema.Float64Attribute{
+				Optional:      true,
+				Description:   "2d index only. Upper bound (exclusive) for location values (server default 180).",
+				PlanModifiers: []planmodifier.Float64{float64planmodifier.RequiresReplace()},
+			},
+			// The server option is 2dsphereIndexVersion, but an HCL attribute
+			// name cannot start with a digit.
+			"sphere_index_version": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "2dsphere index only. 2dsphere index version (server option 2dsphereIndexVersion); the server defaults to the newest it supports.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"wildcard_projection": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString(""),
+				Description:   "Wildcard index only. A JSON string with the fields to include or exclude, e.g. {\"payload.secret\": 0}. Requires a keys entry on \"$**\".",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"storage_engine": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString(""),
+				Description:   storageEngineDescription,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
 			"timeout": schema.Int64Attribute{
-				Optional: true,
-				Computed: true,
-				Default:  int64default.StaticInt64(30),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(30),
+				Description: "Seconds to wait for the index build. Ignored when wait_for_build is true.",
+			},
+			// commit_quorum and wait_for_build only affect how the index is
+			// built, so changing them never rebuilds it.
+			"commit_quorum": schema.StringAttribute{
+				Optional:    true,
+				Description: "Replica sets only. Data-bearing voting members that must finish the build before the primary commits it: \"votingMembers\" (server default), \"majority\", a number, or a replica set tag name.",
+			},
+			"wait_for_build": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Wait for the index build to finish however long it takes, logging progress from $currentOp, instead of failing after timeout seconds.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "On create, take an existing index with the same name (or, when name is unset, the same keys) into state instead of building it. Fails if its options differ from the configuration.",
 			},
 		},
 		Blocks: map[string]schema.Block{
@@ -126,6 +281,7 @@ func (r *dbIndexResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					},
 				},
 			},
+			"collation": collationBlock("Collation for string comparisons in this index, e.g. strength 2 for a case-insensitive unique index. Omit to inherit the collection's default collation."),
 		},
 	}
 }
@@ -157,13 +313,40 @@ func (r *dbIndexResource) Create(ctx context.Context, req resource.CreateRequest
 
 	db := plan.Db.ValueString()
 	collectionName := plan.Collection.ValueString()
+
+	if plan.AdoptExisting.ValueBool() {
+		existingName, err := r.findExistingIndex(ctx, client, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Could not adopt the existing index", err.Error())
+			return
+		}
+		if existingName != "" {
+			existing := plan
+			existing.ID = types.StringValue(dbIndexId(db, collectionName, existingName))
+			if err := r.readIndexInto(client, &existing); err != nil {
+				resp.Diagnostics.AddError("Error reading the existing index", err.Error())
+				return
+			}
+			if differences := indexDifferences(plan, existing); len(differences) > 0 {
+				resp.Diagnostics.AddError("Existing index conflicts with the configuration",
+					fmt.Sprintf("Index %q already exists on %s.%s with different options:\n  %s\n\nChange the configuration to match, or drop the index, before adopting it.",
+						existingName, db, collectionName, strings.Join(differences, "\n  ")))
+				return
+			}
+			tflog.Info(ctx, "adopted existing index", map[string]interface{}{"index": existingName})
+			resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: existing.ID})...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &existing)...)
+			return
+		}
+	}
+
 	indexName, err := r.createIndex(ctx, client, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Could not create the index", err.Error())
 		return
 	}
 
-	plan.ID = types.StringValue(base64.StdEncoding.EncodeToString([]byte(strings.Join([]string{db, collectionName, indexName}, "."))))
+	plan.ID = types.StringValue(dbIndexId(db, collectionName, indexName))
 	if err := r.readIndexInto(client, &plan); err != nil {
 		resp.Diagnostics.AddError("Error reading index after create", err.Error())
 		return
@@ -186,9 +369,21 @@ func (r *dbIndexResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	if err := r.readIndexInto(client, &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading index", err.Error())
 		return
 	}
+	// wait_for_build and adopt_existing are client-side; give imported
+	// indexes the defaults.
+	if state.WaitForBuild.IsNull() {
+		state.WaitForBuild = types.BoolValue(false)
+	}
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(false)
+	}
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -207,22 +402,28 @@ func (r *dbIndexResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Only the hidden flag is mutable in place; everything else forces replacement.
+	db, collectionName, indexName, parseErr := resourceDatabaseIndexParseId(state.ID.ValueString())
+	if parseErr != nil {
+		resp.Diagnostics.AddError("ID mismatch", parseErr.Error())
+		return
+	}
+
+	// hidden and the TTL are mutable in place; everything else forces replacement.
 	if !plan.Hidden.Equal(state.Hidden) {
-		db, collectionName, indexName, parseErr := resourceDatabaseIndexParseId(state.ID.ValueString())
-		if parseErr != nil {
-			resp.Diagnostics.AddError("ID mismatch", parseErr.Error())
+		if err := collModIndex(client, db, collectionName, indexName, bson.E{Key: "hidden", Value: plan.Hidden.ValueBool()}); err != nil {
+			resp.Diagnostics.AddError("Failed to update index hidden state", err.Error())
+			return
+		}
+	}
+	if plan.Unique.ValueBool() && !state.Unique.ValueBool() {
+		if err := r.convertToUnique(ctx, client, db, collectionName, indexName, &state); err != nil {
+			resp.Diagnostics.AddError("Failed to make the index unique", err.Error())
 			return
 		}
-		result := client.Database(db).RunCommand(context.Background(), bson.D{
-			{Key: "collMod", Value: collectionName},
-			{Key: "index", Value: bson.D{
-				{Key: "name", Value: indexName},
-				{Key: "hidden", Value: plan.Hidden.ValueBool()},
-			}},
-		})
-		if result.Err() != nil {
-			resp.Diagnostics.AddError("Failed to update index hidden state", result.Err().Error())
+	}
+	if !plan.ExpireAfterSeconds.IsNull() && !plan.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) {
+		if err := collModIndex(client, db, collectionName, indexName, bson.E{Key: "expireAfterSeconds", Value: plan.ExpireAfterSeconds.ValueInt64()}); err != nil {
+			resp.Diagnostics.AddError("Failed to update index expireAfterSeconds", err.Error())
 			return
 		}
 	}
@@ -235,6 +436,219 @@ func (r *dbIndexResource) Update(ctx context.Context, req resource.UpdateRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+func dbIndexId(db, collectionName, indexName string) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Join([]string{db, collectionName, indexName}, ".")))
+}
+
+// findExistingIndex returns the name of the index adopt_existing should take
+// over: the configured name if such an index exists, otherwise an index with
+// the configured keys. It returns "" when there is nothing to adopt, and an
+// error when the keys are already indexed under another name.
+func (r *dbIndexResource) findExistingIndex(ctx context.Context, client *mongo.Client, plan *dbIndexResourceModel) (string, error) {
+	var keys []dbIndexKeyModel
+	if diags := plan.Keys.ElementsAs(ctx, &keys, false); diags.HasError() {
+		return "", fmt.Errorf("invalid keys")
+	}
+
+	// A collection that does not exist yet has no index to adopt.
+	results, err := listIndexSpecs(client, plan.Db.ValueString(), plan.Collection.ValueString())
+	if err != nil && !isNotFound(err) {
+		return "", err
+	}
+
+	name := plan.Name.ValueString()
+	sameKeys := ""
+	for _, result := range results {
+		existingName, _ := result["name"].(string)
+		if name != "" && existingName == name {
+			return name, nil
+		}
+		keyD, _ := result["key"].(bson.D)
+		weightsD, _ := result["weights"].(bson.D)
+		if sameKeys == "" && indexKeysEqual(indexKeysFromSpec(keyD, weightsD, keys), keys) {
+			sameKeys = existingName
+		}
+	}
+	if sameKeys != "" && name != "" {
+		return "", fmt.Errorf("an index with the same keys already exists as %q; set name = %q to adopt it", sameKeys, sameKeys)
+	}
+	return sameKeys, nil
+}
+
+func indexKeysEqual(a, b []dbIndexKeyModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Field.ValueString() != b[i].Field.ValueString() || a[i].Value.ValueString() != b[i].Value.ValueString() {
+			return false
+		}
+	}
+	return true
+}
+
+// indexDifferences lists the configured index attributes that an existing
+// index does not match. Values the plan leaves to the server are skipped.
+func indexDifferences(plan, existing dbIndexResourceModel) []string {
+	pairs := []struct {
+		name            string
+		planned, actual attr.Value
+	}{
+		{"keys", plan.Keys, existing.Keys},
+		{"unique", plan.Unique, existing.Unique},
+		{"sparse", plan.Sparse, existing.Sparse},
+		{"expire_after_seconds", plan.ExpireAfterSeconds, existing.ExpireAfterSeconds},
+		{"partial_filter_expression", plan.PartialFilterExpression, existing.PartialFilterExpression},
+		{"hidden", plan.Hidden, existing.Hidden},
+		{"collation", collationKnownFrom(plan.Collation, existing.Collation), existing.Collation},
+		{"weights", plan.Weights, existing.Weights},
+		{"default_language", plan.DefaultLanguage, existing.DefaultLanguage},
+		{"language_override", plan.LanguageOverride, existing.LanguageOverride},
+		{"text_index_version", plan.TextIndexVersion, existing.TextIndexVersion},
+		{"bits", plan.Bits, existing.Bits},
+		{"min", plan.Min, existing.Min},
+		{"max", plan.Max, existing.Max},
+		{"sphere_index_version", plan.SphereIndexVersion, existing.SphereIndexVersion},
+		{"wildcard_projection", plan.WildcardProjection, existing.WildcardProjection},
+		{"storage_engine", plan.StorageEngine, existing.StorageEngine},
+	}
+	var differences []string
+	for _, p := range pairs {
+		if p.planned.IsUnknown() || p.planned.Equal(p.actual) {
+			continue
+		}
+		differences = append(differences, fmt.Sprintf("%s: configured %s, existing %s", p.name, p.planned, p.actual))
+	}
+	return differences
+}
+
+// collationKnownFrom fills the collation fields the plan leaves to the server
+// with the existing index's values, so only configured fields are compared.
+func collationKnownFrom(planned, actual types.List) types.List {
+	if planned.IsUnknown() || len(planned.Elements()) != 1 || len(actual.Elements()) != 1 {
+		return planned
+	}
+	plannedAttrs := planned.Elements()[0].(types.Object).Attributes()
+	actualAttrs := actual.Elements()[0].(types.Object).Attributes()
+	merged := map[string]attr.Value{}
+	for name, value := range plannedAttrs {
+		if value.IsUnknown() {
+			value = actualAttrs[name]
+		}
+		merged[name] = value
+	}
+	return types.ListValueMust(collationObjectType, []attr.Value{types.ObjectValueMust(collationObjectType.AttrTypes, merged)})
+}
+
+// maxReportedDuplicates caps how many duplicate keys a failed unique
+// conversion lists.
+const maxReportedDuplicates = 10
+
+// convertToUnique makes an existing index unique without a rebuild: collMod
+// prepareUnique makes the index reject new duplicates, then unique: true
+// converts it once existing duplicates are ruled out. If the conversion fails
+// the index is returned to its prior state and the duplicate keys are listed.
+func (r *dbIndexResource) convertToUnique(ctx context.Context, client *mongo.Client, db, collectionName, indexName string, state *dbIndexResourceModel) error {
+	if err := collModIndex(client, db, collectionName, indexName, bson.E{Key: "prepareUnique", Value: true}); err != nil {
+		return fmt.Errorf("collMod prepareUnique (requires MongoDB 6.0+): %s", err)
+	}
+	convertErr := collModIndex(client, db, collectionName, indexName, bson.E{Key: "unique", Value: true})
+	if convertErr == nil {
+		return nil
+	}
+
+	// Best effort: without this the index keeps rejecting duplicate inserts.
+	_ = collModIndex(client, db, collectionName, indexName, bson.E{Key: "prepareUnique", Value: false})
+
+	var keys []dbIndexKeyModel
+	if diags := state.Keys.ElementsAs(ctx, &keys, false); diags.HasError() {
+		return convertErr
+	}
+	fields := make([]string, 0, len(keys))
+	for _, k := range keys {
+		fields = append(fields, k.Field.ValueString())
+	}
+	duplicates, err := findDuplicateIndexKeys(client, db, collectionName, fields, state.PartialFilterExpression.ValueString(), state.Sparse.ValueBool(), maxReportedDuplicates)
+	if err != nil || len(duplicates) == 0 {
+		return convertErr
+	}
+	return fmt.Errorf("%s\n\nThe collection holds duplicate keys for index %q (showing up to %d); remove or fix these documents and apply again:\n  %s",
+		convertErr, indexName, maxReportedDuplicates, strings.Join(duplicates, "\n  "))
+}
+
+// findDuplicateIndexKeys returns up to limit key values that more than one
+// document shares, formatted as Extended JSON with their document count. The
+// partial filter and sparse option restrict the search to indexed documents.
+func findDuplicateIndexKeys(client *mongo.Client, db, collectionName string, fields []string, partialFilter string, sparse bool, limit int) ([]string, error) {
+	pipeline := mongo.Pipeline{}
+	if partialFilter != "" {
+		var filterDoc bson.D
+		if err := bson.UnmarshalExtJSON([]byte(partialFilter), false, &filterDoc); err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: filterDoc}})
+	}
+	if sparse {
+		exists := bson.A{}
+		for _, field := range fields {
+			exists = append(exists, bson.D{{Key: field, Value: bson.D{{Key: "$exists", Value: true}}}})
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "$or", Value: exists}}}})
+	}
+	// $group keys cannot contain dots, so fields are grouped by position.
+	group := bson.D{}
+	for i, field := range fields {
+		group = append(group, bson.E{Key: fmt.Sprintf("k%d", i), Value: "$" + field})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: group},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{{Key: "$match", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+
+	cursor, err := client.Database(db).Collection(collectionName).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		ID    bson.D `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(context.Background(), &results); err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]string, 0, len(results))
+	for _, result := range results {
+		key := bson.D{}
+		for i, elem := range result.ID {
+			if i < len(fields) {
+				key = append(key, bson.E{Key: fields[i], Value: elem.Value})
+			}
+		}
+		keyJSON, err := bson.MarshalExtJSON(key, false, false)
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, fmt.Sprintf("%s (%d documents)", keyJSON, result.Count))
+	}
+	return duplicates, nil
+}
+
+// collModIndex changes one option of an existing index with collMod.
+func collModIndex(client *mongo.Client, db, collectionName, indexName string, option bson.E) error {
+	return client.Database(db).RunCommand(context.Background(), bson.D{
+		{Key: "collMod", Value: collectionName},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: indexName},
+			option,
+		}},
+	}).Err()
+}
+
 func (r *dbIndexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state dbIndexResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -267,9 +681,63 @@ func (r *dbIndexResource) ImportState(ctx context.Context, req resource.ImportSt
 func (r *dbIndexResource) createIndex(ctx context.Context, client *mongo.Client, plan *dbIndexResourceModel) (string, error) {
 	collectionClient := client.Database(plan.Db.ValueString()).Collection(plan.Collection.ValueString())
 
+	indexModel, err := indexModelFromPlan(ctx, plan)
+	if err != nil {
+		return "", err
+	}
+	createOptions := createIndexesOptions(plan.CommitQuorum.ValueString())
+
+	db, collectionName := plan.Db.ValueString(), plan.Collection.ValueString()
+	indexName := plan.Name.ValueString()
+	if indexName == "" {
+		indexName = defaultIndexName(indexModel.Keys.(bson.D))
+	}
+
+	// Without wait_for_build the whole create, including any wait for an
+	// earlier build, is bounded by timeout.
+	cctx, cancel := ctx, context.CancelFunc(func() {})
+	timeout := int(plan.Timeout.ValueInt64())
+	if !plan.WaitForBuild.ValueBool() {
+		cctx, cancel = context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	}
+	defer cancel()
+	create := func() (string, error) {
+		if plan.WaitForBuild.ValueBool() {
+			return createIndexAndWatch(cctx, client, collectionClient, indexModel, createOptions, indexName)
+		}
+		return collectionClient.Indexes().CreateOne(cctx, indexModel, createOptions)
+	}
+
+	name, err := create()
+	if isIndexBuildInProgress(err) {
+		// A build left running by an earlier, timed-out apply is adopted
+		// rather than started again. Creating the index once it finishes is
+		// a no-op if the build succeeded and reports why if it failed.
+		tflog.Info(ctx, "adopting in-progress index build", map[string]interface{}{"index": indexName})
+		if waitErr := waitForIndexBuild(cctx, client, db, collectionName, indexName); waitErr != nil && cctx.Err() == nil {
+			return "", fmt.Errorf("index %q is already being built and the build could not be followed: %w", indexName, waitErr)
+		}
+		if cctx.Err() == nil {
+			name, err = create()
+		}
+	}
+	if err != nil && errors.Is(cctx.Err(), context.DeadlineExceeded) {
+		progress := ""
+		if op, opErr := findIndexBuild(context.Background(), client, db, collectionName, indexName); opErr == nil && op != nil && op.Progress.Total > 0 {
+			progress = fmt.Sprintf(" It is %d%% done.", op.Progress.Done*100/op.Progress.Total)
+		}
+		return "", fmt.Errorf("index %q was not built within %d seconds (%w).%s The build continues on the server and the next apply adopts it; set wait_for_build = true to wait for long builds", indexName, timeout, err, progress)
+	}
+	return name, err
+}
+
+// indexModelFromPlan builds the createIndexes entry for an index: its keys in
+// declared order and every option the plan sets. Options left null or at
+// their zero value are omitted so the server applies its defaults.
+func indexModelFromPlan(ctx context.Context, plan *dbIndexResourceModel) (mongo.IndexModel, error) {
 	var keys []dbIndexKeyModel
 	if diags := plan.Keys.ElementsAs(ctx, &keys, false); diags.HasError() {
-		return "", fmt.Errorf("invalid keys")
+		return mongo.IndexModel{}, fmt.Errorf("invalid keys")
 	}
 
 	indexOptions := options.Index()
@@ -278,24 +746,7 @@ func (r *dbIndexResource) createIndex(ctx context.Context, client *mongo.Client,
 		keyField := k.Field.ValueString()
 		value := k.Value.ValueString()
 
-		if keyField == "expireAfterSeconds" {
-			valueInt, err := strconv.Atoi(value)
-			if err != nil {
-				return "", fmt.Errorf("expireAfterSeconds value must be integer : %s ", err)
-			}
-			if valueInt >= 0 {
-				indexOptions.SetExpireAfterSeconds(int32(valueInt))
-				continue
-			}
-		}
-
-		if strings.ToLower(keyField) == "unique" && (strings.ToLower(value) == "true" || strings.ToLower(value) == "false") {
-			indexOptions.SetUnique(strings.ToLower(value) == "true")
-			continue
-		} else if strings.ToLower(keyField) == "sparse" && (strings.ToLower(value) == "true" || strings.ToLower(value) == "false") {
-			indexOptions.SetSparse(strings.ToLower(value) == "true")
-			continue
-		} else if value == "1" {
+		if value == "1" {
 			indexKeys = append(indexKeys, bson.E{Key: keyField, Value: 1})
 		} else if value == "-1" {
 			indexKeys = append(indexKeys, bson.E{Key: keyField, Value: -1})
@@ -315,102 +766,364 @@ func (r *dbIndexResource) createIndex(ctx context.Context, client *mongo.Client,
 	if partialFilter := plan.PartialFilterExpression.ValueString(); len(partialFilter) > 0 {
 		var filterDoc bson.D
 		if err := bson.UnmarshalExtJSON([]byte(partialFilter), false, &filterDoc); err != nil {
-			return "", fmt.Errorf("Invalid partial_filter_expression JSON: %s", err)
+			return mongo.IndexModel{}, fmt.Errorf("Invalid partial_filter_expression JSON: %s", err)
 		}
 		indexOptions.SetPartialFilterExpression(filterDoc)
 	}
 
+	if projection := plan.WildcardProjection.ValueString(); len(projection) > 0 {
+		var projectionDoc bson.D
+		if err := bson.UnmarshalExtJSON([]byte(projection), false, &projectionDoc); err != nil {
+			return mongo.IndexModel{}, fmt.Errorf("Invalid wildcard_projection JSON: %s", err)
+		}
+		indexOptions.SetWildcardProjection(projectionDoc)
+	}
+
+	storageEngine, err := storageEngineDocument(plan.StorageEngine.ValueString())
+	if err != nil {
+		return mongo.IndexModel{}, err
+	}
+	if storageEngine != nil {
+		indexOptions.SetStorageEngine(storageEngine)
+	}
+
 	if plan.Hidden.ValueBool() {
 		indexOptions.SetHidden(true)
 	}
+	if plan.Unique.ValueBool() {
+		indexOptions.SetUnique(true)
+	}
+	if plan.Sparse.ValueBool() {
+		indexOptions.SetSparse(true)
+	}
+	if !plan.ExpireAfterSeconds.IsNull() {
+		indexOptions.SetExpireAfterSeconds(int32(plan.ExpireAfterSeconds.ValueInt64()))
+	}
+	if !plan.Weights.IsNull() && !plan.Weights.IsUnknown() {
+		weights := map[string]int64{}
+		if diags := plan.Weights.ElementsAs(ctx, &weights, false); diags.HasError() {
+			return mongo.IndexModel{}, fmt.Errorf("invalid weights")
+		}
+		weightsDoc := bson.M{}
+		for field, weight := range weights {
+			weightsDoc[field] = int32(weight)
+		}
+		indexOptions.SetWeights(weightsDoc)
+	}
+	if language := plan.DefaultLanguage.ValueString(); language != "" {
+		indexOptions.SetDefaultLanguage(language)
+	}
+	if override := plan.LanguageOverride.ValueString(); override != "" {
+		indexOptions.SetLanguageOverride(override)
+	}
+	if version := plan.TextIndexVersion.ValueInt64(); version > 0 {
+		indexOptions.SetTextVersion(int32(version))
+	}
+	if !plan.Bits.IsNull() {
+		indexOptions.SetBits(int32(plan.Bits.ValueInt64()))
+	}
+	if !plan.Min.IsNull() {
+		indexOptions.SetMin(plan.Min.ValueFloat64())
+	}
+	if !plan.Max.IsNull() {
+		indexOptions.SetMax(plan.Max.ValueFloat64())
+	}
+	if version := plan.SphereIndexVersion.ValueInt64(); version > 0 {
+		indexOptions.SetSphereVersion(int32(version))
+	}
+	collation, diags := collationFromList(ctx, plan.Collation)
+	if diags.HasError() {
+		return mongo.IndexModel{}, fmt.Errorf("invalid collation")
+	}
+	if collation != nil {
+		indexOptions.SetCollation(collation)
+	}
 
-	indexModel := mongo.IndexModel{Keys: indexKeys, Options: indexOptions}
+	return mongo.IndexModel{Keys: indexKeys, Options: indexOptions}, nil
+}
 
-	timeout := int(plan.Timeout.ValueInt64())
-	cctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+// createIndexesOptions applies commit_quorum, which is either a member count
+// or a named quorum such as "majority" or a replica set tag.
+func createIndexesOptions(quorum string) *options.CreateIndexesOptionsBuilder {
+	createOptions := options.CreateIndexes()
+	if quorum != "" {
+		if members, err := strconv.Atoi(quorum); err == nil {
+			createOptions.SetCommitQuorumInt(int32(members))
+		} else {
+			createOptions.SetCommitQuorumString(quorum)
+		}
+	}
+	return createOptions
+}
 
-	return collectionClient.Indexes().CreateOne(cctx, indexModel)
+// createIndexAndWatch runs createIndexes without a deadline and logs the
+// build's progress until it returns. Progress is read from $currentOp, which
+// needs the inprog privilege; without it the build runs unlogged.
+func createIndexAndWatch(ctx context.Context, client *mongo.Client, collectionClient *mongo.Collection, model mongo.IndexModel, createOptions *options.CreateIndexesOptionsBuilder, indexName string) (string, error) {
+	type createResult struct {
+		name string
+		err  error
+	}
+	done := make(chan createResult, 1)
+	go func() {
+		name, err := collectionClient.Indexes().CreateOne(ctx, model, createOptions)
+		done <- createResult{name, err}
+	}()
+
+	db, collectionName := collectionClient.Database().Name(), collectionClient.Name()
+	poll := time.After(indexBuildPollInterval)
+	for {
+		select {
+		case result := <-done:
+			return result.name, result.err
+		case <-poll:
+			op, err := findIndexBuild(ctx, client, db, collectionName, indexName)
+			if err != nil {
+				tflog.Warn(ctx, "cannot log index build progress", map[string]interface{}{"error": err.Error()})
+				poll = nil
+				continue
+			}
+			if op != nil {
+				logIndexBuildProgress(ctx, db, collectionName, indexName, op)
+			}
+			poll = time.After(indexBuildPollInterval)
+		}
+	}
 }
 
-// readIndexInto mirrors the SDKv2 read: it rebuilds keys (including the unique
-// and expireAfterSeconds pseudo-entries) in the same order, and marshals
-// partialFilterExpression back with the identical bson.MarshalExtJSON call so
-// the value round-trips without a diff. Leaves timeout and id as-is.
+// readIndexInto finds the index named by the ID and reads it with
+// readIndexSpecInto. Leaves timeout and id as-is.
 func (r *dbIndexResource) readIndexInto(client *mongo.Client, m *dbIndexResourceModel) error {
 	db, collectionName, indexName, err := resourceDatabaseIndexParseId(m.ID.ValueString())
 	if err != nil {
 		return err
 	}
 
-	collectionClient := client.Database(db).Collection(collectionName)
-	cursor, err := collectionClient.Indexes().List(context.Background())
+	results, err := listIndexSpecs(client, db, collectionName)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if name, ok := result["name"]; !ok || name != indexName {
+			continue
+		}
+		m.Db = types.StringValue(db)
+		m.Collection = types.StringValue(collectionName)
+		m.Name = types.StringValue(indexName)
+		return readIndexSpecInto(client, db, collectionName, result, m)
+	}
+	return notFoundError{kind: "index"}
+}
+
+// listIndexSpecs returns the listIndexes entries of a collection.
+func listIndexSpecs(client *mongo.Client, db, collectionName string) ([]bson.M, error) {
+	cursor, err := client.Database(db).Collection(collectionName).Indexes().List(context.Background())
 	if err != nil {
-		return fmt.Errorf("Failed to list indexes: %s", err)
+		return nil, fmt.Errorf("Failed to list indexes: %w", err)
 	}
 	var results []bson.M
 	if err = cursor.All(context.Background(), &results); err != nil {
-		return fmt.Errorf("Failed to list indexes: %s", err)
+		return nil, fmt.Errorf("Failed to list indexes: %w", err)
 	}
+	return results, nil
+}
 
-	found := false
-	var keyValues []attr.Value
-	for _, result := range results {
-		if name, ok := result["name"]; !ok || name != indexName {
-			continue
+// readIndexSpecInto reads one listIndexes entry into m: keys in index order
+// and the typed options. partialFilterExpression is marshalled back with the
+// identical bson.MarshalExtJSON call so the value round-trips without a diff.
+// The values already in m are the prior state, used to keep declared
+// spellings and to hide server defaults that were never configured.
+func readIndexSpecInto(client *mongo.Client, db, collectionName string, result bson.M, m *dbIndexResourceModel) error {
+	var priorKeys []dbIndexKeyModel
+	if !m.Keys.IsNull() && !m.Keys.IsUnknown() {
+		if diags := m.Keys.ElementsAs(context.Background(), &priorKeys, false); diags.HasError() {
+			return fmt.Errorf("invalid keys")
 		}
+	}
 
-		if keyD, ok := result["key"].(bson.D); ok {
-			for _, elem := range keyD {
-				keyValues = append(keyValues, mustIndexKeyObject(elem.Key, fmt.Sprintf("%v", elem.Value)))
-			}
+	var err error
+	var keyValues []attr.Value
+	keyD, _ := result["key"].(bson.D)
+	weightsD, _ := result["weights"].(bson.D)
+	for _, k := range indexKeysFromSpec(keyD, weightsD, priorKeys) {
+		keyValues = append(keyValues, mustIndexKeyObject(k.Field.ValueString(), k.Value.ValueString()))
+	}
+	m.Weights = indexWeightsFromSpec(weightsD, m.Weights)
+	if language, ok := result["default_language"].(string); ok {
+		m.DefaultLanguage = types.StringValue(language)
+	} else {
+		m.DefaultLanguage = types.StringNull()
+	}
+	if override, ok := result["language_override"].(string); ok {
+		m.LanguageOverride = types.StringValue(override)
+	} else {
+		m.LanguageOverride = types.StringNull()
+	}
+	if version, ok := bsonNumberToInt64(result["textIndexVersion"]); ok {
+		m.TextIndexVersion = types.Int64Value(version)
+	} else {
+		m.TextIndexVersion = types.Int64Null()
+	}
+	// The 2d options are only reported when set at creation.
+	if bits, ok := bsonNumberToInt64(result["bits"]); ok {
+		m.Bits = types.Int64Value(bits)
+	} else {
+		m.Bits = types.Int64Null()
+	}
+	if lower, ok := bsonNumberToFloat64(result["min"]); ok {
+		m.Min = types.Float64Value(lower)
+	} else {
+		m.Min = types.Float64Null()
+	}
+	if upper, ok := bsonNumberToFloat64(result["max"]); ok {
+		m.Max = types.Float64Value(upper)
+	} else {
+		m.Max = types.Float64Null()
+	}
+	if version, ok := bsonNumberToInt64(result["2dsphereIndexVersion"]); ok {
+		m.SphereIndexVersion = types.Int64Value(version)
+	} else {
+		m.SphereIndexVersion = types.Int64Null()
+	}
+	unique, _ := result["unique"].(bool)
+	m.Unique = types.BoolValue(unique)
+	sparse, _ := result["sparse"].(bool)
+	m.Sparse = types.BoolValue(sparse)
+	if expireAfter, ok := bsonNumberToInt64(result["expireAfterSeconds"]); ok {
+		m.ExpireAfterSeconds = types.Int64Value(expireAfter)
+	} else {
+		m.ExpireAfterSeconds = types.Int64Null()
+	}
+	var collation *collationDoc
+	if c, ok := result["collation"]; ok {
+		raw, marshalErr := bson.Marshal(c)
+		if marshalErr != nil {
+			return fmt.Errorf("failed to read index collation : %s", marshalErr)
+		}
+		if collation, err = decodeCollation(raw); err != nil {
+			return err
 		}
-		if unique, ok := result["unique"]; ok {
-			keyValues = append(keyValues, mustIndexKeyObject("unique", fmt.Sprintf("%v", unique)))
+	}
+	// An index created without a collation inherits the collection's
+	// default and reports it; keep that out of state unless declared.
+	if collation != nil && collationLocale(m.Collation) == "" {
+		inherited, collErr := collectionCollation(client, db, collectionName)
+		if collErr != nil {
+			return collErr
 		}
-		if sparse, ok := result["sparse"]; ok {
-			keyValues = append(keyValues, mustIndexKeyObject("sparse", fmt.Sprintf("%v", sparse)))
+		if inherited != nil && *inherited == *collation {
+			collation = nil
 		}
-		if expireAfter, ok := result["expireAfterSeconds"]; ok {
-			keyValues = append(keyValues, mustIndexKeyObject("expireAfterSeconds", fmt.Sprintf("%v", expireAfter)))
+	}
+	m.Collation = collationList(collation, m.Collation)
+	if pfe, ok := result["partialFilterExpression"]; ok {
+		if pfeBytes, marshalErr := bson.MarshalExtJSON(pfe, false, false); marshalErr == nil {
+			m.PartialFilterExpression = types.StringValue(string(pfeBytes))
 		}
-		if pfe, ok := result["partialFilterExpression"]; ok {
-			if pfeBytes, marshalErr := bson.MarshalExtJSON(pfe, false, false); marshalErr == nil {
-				m.PartialFilterExpression = types.StringValue(string(pfeBytes))
+	} else {
+		// No partial filter on the index: pin to "" (the default) so state
+		// matches create-time and import round-trips without a diff.
+		m.PartialFilterExpression = types.StringValue("")
+	}
+	if projection, ok := result["wildcardProjection"]; ok {
+		if projectionBytes, marshalErr := bson.MarshalExtJSON(projection, false, false); marshalErr == nil {
+			// Keep the configured spelling when it describes the same
+			// document, e.g. {"a": true} read back as {"a": 1}.
+			if !sameWildcardProjection(m.WildcardProjection.ValueString(), string(projectionBytes)) {
+				m.WildcardProjection = types.StringValue(string(projectionBytes))
 			}
-		} else {
-			// No partial filter on the index: pin to "" (the default) so state
-			// matches create-time and import round-trips without a diff.
-			m.PartialFilterExpression = types.StringValue("")
 		}
-		if hidden, ok := result["hidden"]; ok {
-			if hiddenBool, isBool := hidden.(bool); isBool {
-				m.Hidden = types.BoolValue(hiddenBool)
-			}
-		} else {
-			m.Hidden = types.BoolValue(false)
+	} else {
+		m.WildcardProjection = types.StringValue("")
+	}
+	var storageEngineRaw bson.Raw
+	if storageEngine, ok := result["storageEngine"]; ok {
+		if storageEngineRaw, err = bson.Marshal(storageEngine); err != nil {
+			return fmt.Errorf("failed to read index storage engine options : %s", err)
 		}
-
-		found = true
-		break
 	}
-
-	if !found {
-		return fmt.Errorf("index does not exist")
+	if m.StorageEngine, err = storageEngineString(storageEngineRaw, m.StorageEngine); err != nil {
+		return err
+	}
+	if hidden, ok := result["hidden"]; ok {
+		if hiddenBool, isBool := hidden.(bool); isBool {
+			m.Hidden = types.BoolValue(hiddenBool)
+		}
+	} else {
+		m.Hidden = types.BoolValue(false)
 	}
 
 	keysList, diags := types.ListValue(dbIndexKeyObjectType, keyValues)
 	if diags.HasError() {
 		return fmt.Errorf("building keys list")
 	}
-
-	m.Db = types.StringValue(db)
-	m.Collection = types.StringValue(collectionName)
-	m.Name = types.StringValue(indexName)
 	m.Keys = keysList
 	return nil
 }
 
+// indexKeysFromSpec turns a listIndexes key document back into keys entries.
+// A text index reports its fields as _fts/_ftsx plus a weights document; those
+// are expanded into one "text" entry per field, in the order prior declares
+// them when it names the same fields (the server sorts weights by name).
+func indexKeysFromSpec(key, weights bson.D, prior []dbIndexKeyModel) []dbIndexKeyModel {
+	textFields := make([]string, 0, len(weights))
+	weighted := map[string]bool{}
+	for _, w := range weights {
+		textFields = append(textFields, w.Key)
+		weighted[w.Key] = true
+	}
+	var declared []string
+	for _, k := range prior {
+		if k.Value.ValueString() == "text" {
+			declared = append(declared, k.Field.ValueString())
+		}
+	}
+	if len(declared) == len(textFields) {
+		sameFields := true
+		for _, field := range declared {
+			sameFields = sameFields && weighted[field]
+		}
+		if sameFields {
+			textFields = declared
+		}
+	}
+
+	var keys []dbIndexKeyModel
+	for _, elem := range key {
+		switch elem.Key {
+		case "_fts":
+			for _, field := range textFields {
+				keys = append(keys, dbIndexKeyModel{Field: types.StringValue(field), Value: types.StringValue("text")})
+			}
+		case "_ftsx":
+		default:
+			keys = append(keys, dbIndexKeyModel{Field: types.StringValue(elem.Key), Value: types.StringValue(fmt.Sprintf("%v", elem.Value))})
+		}
+	}
+	return keys
+}
+
+// indexWeightsFromSpec keeps the weights that differ from the server default
+// of 1, plus any the configuration declared explicitly, so an unweighted text
+// index reads back as null weights.
+func indexWeightsFromSpec(weights bson.D, prior types.Map) types.Map {
+	declared := prior.Elements()
+	values := map[string]attr.Value{}
+	for _, w := range weights {
+		weight, ok := bsonNumberToInt64(w.Value)
+		if !ok {
+			continue
+		}
+		if _, isDeclared := declared[w.Key]; weight != 1 || isDeclared {
+			values[w.Key] = types.Int64Value(weight)
+		}
+	}
+	if len(values) == 0 && prior.IsNull() {
+		return types.MapNull(types.Int64Type)
+	}
+	return types.MapValueMust(types.Int64Type, values)
+}
+
 func mustIndexKeyObject(field, value string) attr.Value {
 	obj, _ := types.ObjectValue(dbIndexKeyObjectType.AttrTypes, map[string]attr.Value{
 		"field": types.StringValue(field),
@@ -418,3 +1131,253 @@ func mustIndexKeyObject(field, value string) attr.Value {
 	})
 	return obj
 }
+
+// bsonNumberToInt64 accepts the numeric types the server may use for an index
+// option (expireAfterSeconds is stored as whatever type it was created with).
+func bsonNumberToInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// bsonNumberToFloat64 is bsonNumberToInt64 for floating-point options.
+func bsonNumberToFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// ValidateConfig rejects keys entries that schema version 0 treated as index
+// options, and checks wildcard_projection.
+func (r *dbIndexResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var keys types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("keys"), &keys)...)
+	if resp.Diagnostics.HasError() || keys.IsNull() || keys.IsUnknown() {
+		return
+	}
+	var models []dbIndexKeyModel
+	resp.Diagnostics.Append(keys.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	wildcardRoot, keysKnown := false, true
+	for i, k := range models {
+		if k.Field.IsUnknown() || k.Value.IsUnknown() {
+			keysKnown = false
+			continue
+		}
+		if k.Field.ValueString() == "$**" {
+			wildcardRoot = true
+		}
+		// Indexing such an entry as a field would silently replace a
+		// unique or TTL index with a plain one, so it is rejected.
+		if option := legacyIndexOption(k.Field.ValueString(), k.Value.ValueString()); option != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("keys").AtListIndex(i),
+				"Index option in keys",
+				fmt.Sprintf("keys entry %q = %q looks like the index option it used to stand for. Index options are no longer read from keys; remove the entry and set %s = %s instead.",
+					k.Field.ValueString(), k.Value.ValueString(), option, strings.ToLower(k.Value.ValueString())))
+		}
+	}
+
+	var projection types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wildcard_projection"), &projection)...)
+	if resp.Diagnostics.HasError() || projection.IsNull() || projection.IsUnknown() || projection.ValueString() == "" {
+		return
+	}
+	var projectionDoc bson.D
+	if err := bson.UnmarshalExtJSON([]byte(projection.ValueString()), false, &projectionDoc); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("wildcard_projection"), "Invalid wildcard_projection", err.Error())
+		return
+	}
+	// A projection is only accepted on the all-fields wildcard key; a
+	// path.$** key already limits the index to one subtree.
+	if keysKnown && !wildcardRoot {
+		resp.Diagnostics.AddAttributeError(path.Root("wildcard_projection"), "wildcard_projection requires a $** key",
+			"wildcard_projection can only be set on a wildcard index with a keys entry whose field is \"$**\".")
+	}
+}
+
+// sameWildcardProjection reports whether two Extended JSON projections name
+// the same fields in the same order with the same include/exclude flags.
+func sameWildcardProjection(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	var docA, docB bson.D
+	if bson.UnmarshalExtJSON([]byte(a), false, &docA) != nil || bson.UnmarshalExtJSON([]byte(b), false, &docB) != nil {
+		return false
+	}
+	if len(docA) != len(docB) {
+		return false
+	}
+	for i := range docA {
+		if docA[i].Key != docB[i].Key || projectionFlag(docA[i].Value) != projectionFlag(docB[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// projectionFlag reduces a projection value to include/exclude; values that
+// are not flags compare by their printed form.
+func projectionFlag(v interface{}) string {
+	switch n := v.(type) {
+	case bool:
+		return strconv.FormatBool(n)
+	case int32, int64, float64:
+		f, _ := bsonNumberToFloat64(n)
+		return strconv.FormatBool(f != 0)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// UpgradeState moves version 0 states, where unique, sparse and
+// expireAfterSeconds were stored as pseudo-entries in keys, onto the typed
+// attributes.
+func (r *dbIndexResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                        schema.StringAttribute{Computed: true},
+					"db":                        schema.StringAttribute{Required: true},
+					"collection":                schema.StringAttribute{Required: true},
+					"name":                      schema.StringAttribute{Optional: true, Computed: true},
+					"partial_filter_expression": schema.StringAttribute{Optional: true, Computed: true},
+					"hidden":                    schema.BoolAttribute{Optional: true, Computed: true},
+					"timeout":                   schema.Int64Attribute{Optional: true, Computed: true},
+				},
+				Blocks: map[string]schema.Block{
+					"keys": schema.ListNestedBlock{
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"field": schema.StringAttribute{Required: true},
+								"value": schema.StringAttribute{Required: true},
+							},
+						},
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior dbIndexResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				var keys []dbIndexKeyModel
+				resp.Diagnostics.Append(prior.Keys.ElementsAs(ctx, &keys, false)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := dbIndexResourceModel{
+					ID:                      prior.ID,
+					Db:                      prior.Db,
+					Collection:              prior.Collection,
+					Name:                    prior.Name,
+					PartialFilterExpression: prior.PartialFilterExpression,
+					Hidden:                  prior.Hidden,
+					Timeout:                 prior.Timeout,
+				}
+				realKeys, options := splitLegacyIndexKeys(keys)
+				upgraded.Unique = types.BoolValue(options.unique)
+				upgraded.Sparse = types.BoolValue(options.sparse)
+				upgraded.ExpireAfterSeconds = types.Int64PointerValue(options.expireAfterSeconds)
+				upgraded.Collation = types.ListValueMust(collationObjectType, []attr.Value{})
+				upgraded.Weights = types.MapNull(types.Int64Type)
+				upgraded.DefaultLanguage = types.StringNull()
+				upgraded.LanguageOverride = types.StringNull()
+				upgraded.TextIndexVersion = types.Int64Null()
+				upgraded.Bits = types.Int64Null()
+				upgraded.Min = types.Float64Null()
+				upgraded.Max = types.Float64Null()
+				upgraded.SphereIndexVersion = types.Int64Null()
+				upgraded.WildcardProjection = types.StringValue("")
+				upgraded.StorageEngine = types.StringValue("")
+				upgraded.CommitQuorum = types.StringNull()
+				upgraded.WaitForBuild = types.BoolValue(false)
+				upgraded.AdoptExisting = types.BoolValue(false)
+
+				keyValues := make([]attr.Value, 0, len(realKeys))
+				for _, k := range realKeys {
+					keyValues = append(keyValues, mustIndexKeyObject(k.Field.ValueString(), k.Value.ValueString()))
+				}
+				keysList, diags := types.ListValue(dbIndexKeyObjectType, keyValues)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				upgraded.Keys = keysList
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
+}
+
+type dbIndexResourceModelV0 struct {
+	ID                      types.String `tfsdk:"id"`
+	Db                      types.String `tfsdk:"db"`
+	Collection              types.String `tfsdk:"collection"`
+	Keys                    types.List   `tfsdk:"keys"`
+	Name                    types.String `tfsdk:"name"`
+	PartialFilterExpression types.String `tfsdk:"partial_filter_expression"`
+	Hidden                  types.Bool   `tfsdk:"hidden"`
+	Timeout                 types.Int64  `tfsdk:"timeout"`
+}
+
+type legacyIndexOptions struct {
+	unique             bool
+	sparse             bool
+	expireAfterSeconds *int64
+}
+
+// splitLegacyIndexKeys separates the pseudo-entries the version 0 schema
+// stored in keys from the real index keys, using the same matching rules the
+// old createIndex applied.
+func splitLegacyIndexKeys(keys []dbIndexKeyModel) ([]dbIndexKeyModel, legacyIndexOptions) {
+	var realKeys []dbIndexKeyModel
+	var options legacyIndexOptions
+	for _, k := range keys {
+		field, value := k.Field.ValueString(), k.Value.ValueString()
+		switch legacyIndexOption(field, value) {
+		case "unique":
+			options.unique = strings.EqualFold(value, "true")
+		case "sparse":
+			options.sparse = strings.EqualFold(value, "true")
+		case "expire_after_seconds":
+			seconds, _ := strconv.ParseInt(value, 10, 64)
+			options.expireAfterSeconds = &seconds
+		default:
+			realKeys = append(realKeys, k)
+		}
+	}
+	return realKeys, options
+}
+
+// legacyIndexOption returns the attribute a version 0 keys entry stood for,
+// or "" for an ordinary index key.
+func legacyIndexOption(field, value string) string {
+	switch {
+	case field == "expireAfterSeconds":
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			return "expire_after_seconds"
+		}
+	case strings.EqualFold(field, "unique") && (strings.EqualFold(value, "true") || strings.EqualFold(value, "false")):
+		return "unique"
+	case strings.EqualFold(field, "sparse") && (strings.EqualFold(value, "true") || strings.EqualFold(value, "false")):
+		return "sparse"
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// indexBuildPollInterval is how often an in-progress index build is checked
+// with $currentOp.
+var indexBuildPollInterval = 10 * time.Second
+
+// indexBuildAlreadyInProgressCode is the server error code for a
+// createIndexes that conflicts with a build of the same index already running
+// (IndexBuildAlreadyInProgress).
+const indexBuildAlreadyInProgressCode = 276
+
+// isIndexBuildInProgress reports whether err is a createIndexes failure
+// caused by a build of the same index that is still running.
+func isIndexBuildInProgress(err error) bool {
+	var se mongo.ServerError
+	return errors.As(err, &se) && se.HasErrorCode(indexBuildAlreadyInProgressCode)
+}
+
+// indexBuildOp is the part of a $currentOp entry that describes an index
+// build's progress.
+type indexBuildOp struct {
+	Ns       string `bson:"ns"`
+	Msg      string `bson:"msg"`
+	Progress struct {
+		Done  int64 `bson:"done"`
+		Total int64 `bson:"total"`
+	} `bson:"progress"`
+}
+
+// findIndexBuild returns the in-progress build of indexName on
+// db.collectionName, or nil when none is running. Running $currentOp needs
+// the inprog privilege.
+func findIndexBuild(ctx context.Context, client *mongo.Client, db, collectionName, indexName string) (*indexBuildOp, error) {
+	cursor, err := client.Database("admin").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{{Key: "allUsers", Value: true}}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "command.createIndexes", Value: collectionName},
+			{Key: "command.indexes.name", Value: indexName},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run $currentOp : %w", err)
+	}
+	var ops []indexBuildOp
+	if err := cursor.All(ctx, &ops); err != nil {
+		return nil, fmt.Errorf("failed to read $currentOp : %w", err)
+	}
+
+	// Both the build itself and any client waiting on createIndexes match;
+	// prefer the entry that carries progress. ns is "db.collection" or
+	// "db.$cmd" depending on the entry.
+	var found *indexBuildOp
+	for i := range ops {
+		if !strings.HasPrefix(ops[i].Ns, db+".") {
+			continue
+		}
+		if found == nil || ops[i].Progress.Total > 0 {
+			found = &ops[i]
+		}
+	}
+	return found, nil
+}
+
+// waitForIndexBuild polls $currentOp until the build of indexName is no
+// longer running or ctx is done, logging its progress. It does not tell
+// whether the build succeeded; creating the index again does.
+func waitForIndexBuild(ctx context.Context, client *mongo.Client, db, collectionName, indexName string) error {
+	for {
+		op, err := findIndexBuild(ctx, client, db, collectionName, indexName)
+		if err != nil {
+			return err
+		}
+		if op == nil {
+			return nil
+		}
+		logIndexBuildProgress(ctx, db, collectionName, indexName, op)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(indexBuildPollInterval):
+		}
+	}
+}
+
+func logIndexBuildProgress(ctx context.Context, db, collectionName, indexName string, op *indexBuildOp) {
+	fields := map[string]interface{}{
+		"db":         db,
+		"collection": collectionName,
+		"index":      indexName,
+	}
+	if op.Progress.Total > 0 {
+		fields["done"] = op.Progress.Done
+		fields["total"] = op.Progress.Total
+		fields["percent"] = op.Progress.Done * 100 / op.Progress.Total
+	}
+	if op.Msg != "" {
+		fields["phase"] = op.Msg
+	}
+	tflog.Info(ctx, "index build in progress", fields)
+}
+
+// defaultIndexName is the name the server derives for an unnamed index:
+// each key and its value joined by underscores, e.g. "a_1_b_-1".
+func defaultIndexName(keys bson.D) string {
+	parts := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		parts = append(parts, k.Key, fmt.Sprintf("%v", k.Value))
+	}
+	return strings.Join(parts, "_")
+}
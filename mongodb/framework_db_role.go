@@ -21,9 +21,11 @@ import (
 )
 
 var dbRolePrivilegeObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
-	"db":         types.StringType,
-	"collection": types.StringType,
-	"actions":    types.ListType{ElemType: types.StringType},
+	"db":           types.StringType,
+	"collection":   types.StringType,
+	"cluster":      types.BoolType,
+	"any_resource": types.BoolType,
+	"actions":      types.ListType{ElemType: types.StringType},
 }}
 
 var dbRoleInheritedObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
@@ -41,9 +43,11 @@ type dbRoleResourceModel struct {
 }
 
 type dbRolePrivilegeModel struct {
-	Db         types.String `tfsdk:"db"`
-	Collection types.String `tfsdk:"collection"`
-	Actions    types.List   `tfsdk:"actions"`
+	Db          types.String `tfsdk:"db"`
+	Collection  types.String `tfsdk:"collection"`
+	Cluster     types.Bool   `tfsdk:"cluster"`
+	AnyResource types.Bool   `tfsdk:"any_resource"`
+	Actions     types.List   `tfsdk:"actions"`
 }
 
 type dbRoleInheritedModel struct {
@@ -58,10 +62,11 @@ type dbRoleResource struct {
 func newDBRoleResource() resource.Resource { return &dbRoleResource{} }
 
 var (
-	_ resource.Resource                = &dbRoleResource{}
-	_ resource.ResourceWithConfigure   = &dbRoleResource{}
-	_ resource.ResourceWithImportState = &dbRoleResource{}
-	_ resource.ResourceWithIdentity    = &dbRoleResource{}
+	_ resource.Resource                   = &dbRoleResource{}
+	_ resource.ResourceWithConfigure      = &dbRoleResource{}
+	_ resource.ResourceWithImportState    = &dbRoleResource{}
+	_ resource.ResourceWithIdentity       = &dbRoleResource{}
+	_ resource.ResourceWithValidateConfig = &dbRoleResource{}
 )
 
 func (r *dbRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -83,13 +88,17 @@ func (r *dbRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Computed:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
+			// Renaming a role or moving it to another database cannot be done in
+			// place, so either change is planned as a replacement.
 			"database": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
-				Default:  stringdefault.StaticString("admin"),
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("admin"),
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 		},
 		Blocks: map[string]schema.Block{
@@ -98,6 +107,14 @@ func (r *dbRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					Attributes: map[string]schema.Attribute{
 						"db":         schema.StringAttribute{Optional: true},
 						"collection": schema.StringAttribute{Optional: true},
+						"cluster": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Grant the actions on the cluster resource ({ cluster: true }), e.g. for serverStatus or replSetGetStatus. Cannot be combined with db, collection or any_resource.",
+						},
+						"any_resource": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Grant the actions on every resource in the system ({ anyResource: true }). Cannot be combined with db, collection or cluster.",
+						},
 						"actions": schema.ListAttribute{
 							Optional:    true,
 							ElementType: types.StringType,
@@ -137,6 +154,47 @@ func (r *dbRoleResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.config = config
 }
 
+// ValidateConfig rejects privilege blocks that mix resource forms: a privilege
+// targets either db/collection, the cluster, or anyResource. cluster and
+// any_resource may only be set to true: the server stores no false, so it
+// would read back as null and never match the config.
+func (r *dbRoleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var privileges types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("privilege"), &privileges)...)
+	if resp.Diagnostics.HasError() || privileges.IsNull() || privileges.IsUnknown() {
+		return
+	}
+	var models []dbRolePrivilegeModel
+	resp.Diagnostics.Append(privileges.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, m := range models {
+		for name, flag := range map[string]types.Bool{"cluster": m.Cluster, "any_resource": m.AnyResource} {
+			if !flag.IsNull() && !flag.IsUnknown() && !flag.ValueBool() {
+				resp.Diagnostics.AddAttributeError(path.Root("privilege"), "Invalid privilege resource",
+					fmt.Sprintf("%s = false is not supported; remove the attribute instead.", name))
+				return
+			}
+		}
+		forms := 0
+		if !m.Db.IsNull() || !m.Collection.IsNull() {
+			forms++
+		}
+		if m.Cluster.ValueBool() {
+			forms++
+		}
+		if m.AnyResource.ValueBool() {
+			forms++
+		}
+		if forms > 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("privilege"), "Invalid privilege resource",
+				"A privilege must target exactly one resource form: db/collection, cluster = true, or any_resource = true.")
+			return
+		}
+	}
+}
+
 func (r *dbRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan dbRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -192,6 +250,10 @@ func (r *dbRoleResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	if err := r.readRoleInto(client, state.ID.ValueString(), &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading role", err.Error())
 		return
 	}
@@ -213,19 +275,14 @@ func (r *dbRoleResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Mirror the SDKv2 update: drop the existing role, then recreate it.
-	oldRole, oldDatabase, err := resourceDatabaseRoleParseId(state.ID.ValueString())
+	// name and database force replacement, so the role in state is the one to
+	// update; modify it in place so users holding it never lose it.
+	roleName, database, err := resourceDatabaseRoleParseId(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("ID mismatch", err.Error())
 		return
 	}
-	if result := client.Database(oldDatabase).RunCommand(ctx, bson.D{{Key: "dropRole", Value: oldRole}}); result.Err() != nil {
-		resp.Diagnostics.AddError("Could not update the role", result.Err().Error())
-		return
-	}
 
-	roleName := plan.Name.ValueString()
-	database := plan.Database.ValueString()
 	roleList, diags := inheritedFromSet(ctx, plan.InheritedRoles)
 	resp.Diagnostics.Append(diags...)
 	privileges, diags := privilegesFromSet(ctx, plan.Privileges)
@@ -236,7 +293,7 @@ func (r *dbRoleResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	if err := createRole(client, roleName, roleList, privileges, database, authRestrictions); err != nil {
+	if err := updateRole(client, roleName, roleList, privileges, database, authRestrictions); err != nil {
 		resp.Diagnostics.AddError("Could not update the role", err.Error())
 		return
 	}
@@ -290,7 +347,7 @@ func (r *dbRoleResource) readRoleInto(client *mongo.Client, id string, m *dbRole
 		return err
 	}
 	if len(result.Roles) == 0 {
-		return fmt.Errorf("role does not exist")
+		return notFoundError{kind: "role"}
 	}
 
 	inheritedValues := make([]attr.Value, 0, len(result.Roles[0].InheritedRoles))
@@ -323,11 +380,25 @@ func (r *dbRoleResource) readRoleInto(client *mongo.Client, id string, m *dbRole
 		if diags.HasError() {
 			return fmt.Errorf("building privilege actions list")
 		}
-		obj, diags := types.ObjectValue(dbRolePrivilegeObjectType.AttrTypes, map[string]attr.Value{
-			"db":         types.StringValue(s.Resource.Db),
-			"collection": types.StringValue(s.Resource.Collection),
-			"actions":    actionsList,
-		})
+		// Unused resource forms read back as null, matching a block that
+		// leaves them unset.
+		attrs := map[string]attr.Value{
+			"db":           types.StringValue(s.Resource.Db),
+			"collection":   types.StringValue(s.Resource.Collection),
+			"cluster":      types.BoolNull(),
+			"any_resource": types.BoolNull(),
+			"actions":      actionsList,
+		}
+		if s.Resource.Cluster || s.Resource.AnyResource {
+			attrs["db"] = types.StringNull()
+			attrs["collection"] = types.StringNull()
+			if s.Resource.Cluster {
+				attrs["cluster"] = types.BoolValue(true)
+			} else {
+				attrs["any_resource"] = types.BoolValue(true)
+			}
+		}
+		obj, diags := types.ObjectValue(dbRolePrivilegeObjectType.AttrTypes, attrs)
 		if diags.HasError() {
 			return fmt.Errorf("building privilege value")
 		}
@@ -380,9 +451,11 @@ func privilegesFromSet(ctx context.Context, set types.Set) ([]PrivilegeDto, diag
 			diags.Append(m.Actions.ElementsAs(ctx, &actions, false)...)
 		}
 		privileges = append(privileges, PrivilegeDto{
-			Db:         m.Db.ValueString(),
-			Collection: m.Collection.ValueString(),
-			Actions:    actions,
+			Db:          m.Db.ValueString(),
+			Collection:  m.Collection.ValueString(),
+			Cluster:     m.Cluster.ValueBool(),
+			AnyResource: m.AnyResource.ValueBool(),
+			Actions:     actions,
 		})
 	}
 	return privileges, diags
@@ -0,0 +1,361 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// dbRolePrivilegeResourceModel adds one privilege (a resource plus actions) to
+// an existing role without owning the role's other privileges.
+type dbRolePrivilegeResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Database   types.String `tfsdk:"database"`
+	Role       types.String `tfsdk:"role"`
+	Db         types.String `tfsdk:"db"`
+	Collection types.String `tfsdk:"collection"`
+	Actions    types.Set    `tfsdk:"actions"`
+}
+
+type dbRolePrivilegeResource struct {
+	config *MongoDatabaseConfiguration
+}
+
+func newDBRolePrivilegeResource() resource.Resource { return &dbRolePrivilegeResource{} }
+
+var (
+	_ resource.Resource                = &dbRolePrivilegeResource{}
+	_ resource.ResourceWithConfigure   = &dbRolePrivilegeResource{}
+	_ resource.ResourceWithImportState = &dbRolePrivilegeResource{}
+	_ resource.ResourceWithIdentity    = &dbRolePrivilegeResource{}
+)
+
+func (r *dbRolePrivilegeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_role_privilege"
+}
+
+func (r *dbRolePrivilegeResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
+
+func (r *dbRolePrivilegeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Adds a single privilege to an existing role, leaving the role's other privileges untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"database": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("admin"),
+				Description:   "Database the role is defined in.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"role": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the existing role to extend.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"db": schema.StringAttribute{
+				Required:      true,
+				Description:   "Database the privilege applies to.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"collection": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString(""),
+				Description:   "Collection the privilege applies to. Empty grants the actions on every collection in db.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"actions": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Privilege actions to grant on the resource. Can be changed in place.",
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+		},
+	}
+}
+
+func (r *dbRolePrivilegeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(*MongoDatabaseConfiguration)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *MongoDatabaseConfiguration, got %T", req.ProviderData))
+		return
+	}
+	r.config = config
+}
+
+func (r *dbRolePrivilegeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dbRolePrivilegeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	var actions []string
+	resp.Diagnostics.Append(plan.Actions.ElementsAs(ctx, &actions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := plan.Database.ValueString()
+	roleName := plan.Role.ValueString()
+	privilege := PrivilegeDto{Db: plan.Db.ValueString(), Collection: plan.Collection.ValueString(), Actions: actions}
+	if err := grantPrivilegesToRole(client, roleName, database, []PrivilegeDto{privilege}); err != nil {
+		resp.Diagnostics.AddError("Could not grant the privilege", err.Error())
+		return
+	}
+
+	id := dbRolePrivilegeId(database, roleName, privilege.Db, privilege.Collection)
+	state := plan
+	if err := r.readPrivilegeInto(ctx, client, id, &state); err != nil {
+		resp.Diagnostics.AddError("Error reading role privilege after create", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dbRolePrivilegeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dbRolePrivilegeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	if err := r.readPrivilegeInto(ctx, client, state.ID.ValueString(), &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading role privilege", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update grants the newly added actions and revokes the removed ones; every
+// other attribute forces replacement.
+func (r *dbRolePrivilegeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dbRolePrivilegeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	var planned, current []string
+	resp.Diagnostics.Append(plan.Actions.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Actions.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, roleName, db, collection, err := dbRolePrivilegeParseId(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID mismatch", err.Error())
+		return
+	}
+
+	// Grant before revoking so actions kept across the change are never absent.
+	if added := stringsMissingFrom(planned, current); len(added) > 0 {
+		privilege := PrivilegeDto{Db: db, Collection: collection, Actions: added}
+		if err := grantPrivilegesToRole(client, roleName, database, []PrivilegeDto{privilege}); err != nil {
+			resp.Diagnostics.AddError("Could not grant the privilege", err.Error())
+			return
+		}
+	}
+	if removed := stringsMissingFrom(current, planned); len(removed) > 0 {
+		privilege := PrivilegeDto{Db: db, Collection: collection, Actions: removed}
+		if err := revokePrivilegesFromRole(client, roleName, database, []PrivilegeDto{privilege}); err != nil {
+			resp.Diagnostics.AddError("Could not revoke the privilege", err.Error())
+			return
+		}
+	}
+
+	newState := plan
+	if err := r.readPrivilegeInto(ctx, client, state.ID.ValueString(), &newState); err != nil {
+		resp.Diagnostics.AddError("Error reading role privilege after update", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: newState.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *dbRolePrivilegeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dbRolePrivilegeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	var actions []string
+	resp.Diagnostics.Append(state.Actions.ElementsAs(ctx, &actions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, roleName, db, collection, err := dbRolePrivilegeParseId(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID mismatch", err.Error())
+		return
+	}
+	privilege := PrivilegeDto{Db: db, Collection: collection, Actions: actions}
+	if err := revokePrivilegesFromRole(client, roleName, database, []PrivilegeDto{privilege}); err != nil {
+		resp.Diagnostics.AddError("Could not revoke the privilege", err.Error())
+		return
+	}
+}
+
+// ImportState takes the id and leaves actions null; readPrivilegeInto then
+// adopts every action the role holds on the resource.
+func (r *dbRolePrivilegeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// readPrivilegeInto finds the privilege for this resource through rolesInfo
+// (showPrivileges). MongoDB merges the actions of every grant on the same
+// resource, so only the actions this resource declared are kept; anything
+// granted by other means is ignored. When none of the declared actions remain
+// the privilege is reported as not found.
+func (r *dbRolePrivilegeResource) readPrivilegeInto(ctx context.Context, client *mongo.Client, id string, m *dbRolePrivilegeResourceModel) error {
+	database, roleName, db, collection, err := dbRolePrivilegeParseId(id)
+	if err != nil {
+		return err
+	}
+	result, err := getRole(client, roleName, database)
+	if err != nil {
+		return err
+	}
+	if len(result.Roles) == 0 {
+		return notFoundError{kind: "role"}
+	}
+
+	var held []string
+	for _, p := range result.Roles[0].Privileges {
+		if p.Resource.Db == db && p.Resource.Collection == collection {
+			held = p.Actions
+			break
+		}
+	}
+
+	var declared []string
+	if !m.Actions.IsNull() && !m.Actions.IsUnknown() {
+		if diags := m.Actions.ElementsAs(ctx, &declared, false); diags.HasError() {
+			return fmt.Errorf("invalid actions")
+		}
+	}
+
+	actions := held
+	if len(declared) > 0 {
+		actions = stringsMissingFrom(declared, stringsMissingFrom(declared, held))
+	}
+	if len(actions) == 0 {
+		return notFoundError{kind: "role privilege"}
+	}
+	sort.Strings(actions)
+	actionValues := make([]attr.Value, 0, len(actions))
+	for _, a := range actions {
+		actionValues = append(actionValues, types.StringValue(a))
+	}
+	actionSet, diags := types.SetValue(types.StringType, actionValues)
+	if diags.HasError() {
+		return fmt.Errorf("building actions set")
+	}
+
+	m.ID = types.StringValue(id)
+	m.Database = types.StringValue(database)
+	m.Role = types.StringValue(roleName)
+	m.Db = types.StringValue(db)
+	m.Collection = types.StringValue(collection)
+	m.Actions = actionSet
+	return nil
+}
+
+// stringsMissingFrom returns the elements of a that are not in b.
+func stringsMissingFrom(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !in[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// dbRolePrivilegeId encodes [database, role, db, collection] as a base64
+// JSON array, like dbUserRoleGrantId: role and collection names may both
+// contain dots. collection is empty for a database-wide grant.
+func dbRolePrivilegeId(database, roleName, db, collection string) string {
+	encoded, _ := json.Marshal([]string{database, roleName, db, collection})
+	return base64.StdEncoding.EncodeToString(encoded)
+}
+
+// dbRolePrivilegeParseId decodes a dbRolePrivilegeId. Only the trailing
+// collection may be empty.
+func dbRolePrivilegeParseId(id string) (string, string, string, string, error) {
+	result, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("unexpected format of ID Error : %s", err)
+	}
+	var parts []string
+	if err := json.Unmarshal(result, &parts); err != nil || len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", "", fmt.Errorf("unexpected format of ID (%s), expected [database, role, db, collection]", result)
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccMongoDBRolePrivilege_Basic extends a role owned by mongodb_db_role with
+// an extra privilege, changes its actions in place, and verifies the role's own
+// privilege is left alone throughout.
+func TestAccMongoDBRolePrivilege_Basic(t *testing.T) {
+	roleName := acctest.RandomWithPrefix("tf-acc-role")
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_role_privilege.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBRolePrivilege(dbName, roleName, `["find"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "collection", "team_collection"),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "1"),
+					testAccCheckMongoDBRolePrivilegeCount(dbName, roleName, 2),
+				),
+			},
+			{
+				Config: testAccMongoDBRolePrivilege(dbName, roleName, `["find", "insert"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "2"),
+					testAccCheckMongoDBRolePrivilegeCount(dbName, roleName, 2),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDBRolePrivilegeParseId(t *testing.T) {
+	cases := []struct {
+		roleName   string
+		collection string
+	}{
+		{"reporting", ""},
+		{"reporting", "orders"},
+		{"reporting", "events.2024"},
+		{"app.reader", "orders"},
+		{"app.reader", "events.2024"},
+	}
+	for _, tc := range cases {
+		id := dbRolePrivilegeId("admin", tc.roleName, "shop", tc.collection)
+		database, roleName, db, collection, err := dbRolePrivilegeParseId(id)
+		if err != nil {
+			t.Fatalf("%s/%q: %s", tc.roleName, tc.collection, err)
+		}
+		if database != "admin" || roleName != tc.roleName || db != "shop" || collection != tc.collection {
+			t.Errorf("%s/%q: got %s/%s/%s/%q", tc.roleName, tc.collection, database, roleName, db, collection)
+		}
+	}
+}
+
+func testAccCheckMongoDBRolePrivilegeCount(dbName, roleName string, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		result, err := getRole(client, roleName, dbName)
+		if err != nil {
+			return fmt.Errorf("error getting role: %s", err)
+		}
+		if len(result.Roles) == 0 {
+			return fmt.Errorf("role not found: %s", roleName)
+		}
+		if got := len(result.Roles[0].Privileges); got != want {
+			return fmt.Errorf("role %s has %d privileges, want %d", roleName, got, want)
+		}
+		return nil
+	}
+}
+
+func testAccMongoDBRolePrivilege(dbName, roleName, actions string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_role" "test" {
+  database = %[1]q
+  name     = %[2]q
+
+  privilege {
+    db         = %[1]q
+    collection = "shared_collection"
+    actions    = ["find"]
+  }
+
+  # Privileges are authoritative here; ignore those added by other resources.
+  lifecycle {
+    ignore_changes = [privilege]
+  }
+}
+
+resource "mongodb_db_role_privilege" "test" {
+  database   = mongodb_db_role.test.database
+  role       = mongodb_db_role.test.name
+  db         = %[1]q
+  collection = "team_collection"
+  actions    = %[3]s
+}
+`, dbName, roleName, actions)
+}
@@ -298,6 +298,11 @@ func (r *dbUserResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	prevPassword := knownOrEmpty(state.Password)
 	if err := r.readUserInto(client, state.ID.ValueString(), &state); err != nil {
+		if isNotFound(err) {
+			// Dropped outside Terraform: forget it so the next plan re-creates it.
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading user", err.Error())
 		return
 	}
@@ -410,7 +415,7 @@ func (r *dbUserResource) readUserInto(client *mongo.Client, id string, m *dbUser
 		return err
 	}
 	if len(result.Users) == 0 {
-		return fmt.Errorf("user does not exist")
+		return notFoundError{kind: "user"}
 	}
 
 	roleValues := make([]attr.Value, 0, len(result.Users[0].Roles))
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
@@ -100,12 +101,15 @@ resource "mongodb_db_role" "test" {
 `, roleName)
 }
 
-// TestAccMongoDBCollection_list creates a collection, queries the
-// mongodb_db_collection list resource, and asserts it appears.
+// TestAccMongoDBCollection_list creates a collection and a view, queries the
+// mongodb_db_collection list resource, and asserts both appear, the view
+// tagged in its display name.
 func TestAccMongoDBCollection_list(t *testing.T) {
 	dbName := acctest.RandomWithPrefix("tfacclistdb")
 	collName := acctest.RandomWithPrefix("tfacclistcoll")
+	viewName := acctest.RandomWithPrefix("tfacclistview")
 	wantID := base64.StdEncoding.EncodeToString([]byte(dbName + "." + collName))
+	wantViewID := base64.StdEncoding.EncodeToString([]byte(dbName + "." + viewName))
 
 	resource.Test(t, resource.TestCase{
 		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
@@ -121,7 +125,14 @@ resource "mongodb_db_collection" "test" {
   name                = %[2]q
   deletion_protection = false
 }
-`, dbName, collName),
+
+resource "mongodb_db_view" "test" {
+  db       = %[1]q
+  name     = %[3]q
+  view_on  = mongodb_db_collection.test.name
+  pipeline = "[]"
+}
+`, dbName, collName, viewName),
 			},
 			{
 				Query: true,
@@ -136,6 +147,54 @@ list "mongodb_db_collection" "test" {
 					querycheck.ExpectIdentity("mongodb_db_collection.test", map[string]knownvalue.Check{
 						"id": knownvalue.StringExact(wantID),
 					}),
+					querycheck.ExpectResourceDisplayName("mongodb_db_collection.test",
+						queryfilter.ByResourceIdentity(map[string]knownvalue.Check{
+							"id": knownvalue.StringExact(wantViewID),
+						}),
+						knownvalue.StringExact(viewName+" (view)")),
+				},
+			},
+		},
+	})
+}
+
+// TestAccMongoDBView_list creates a view, queries the mongodb_db_view list
+// resource, and asserts it appears.
+func TestAccMongoDBView_list(t *testing.T) {
+	dbName := acctest.RandomWithPrefix("tfacclistdb")
+	viewName := acctest.RandomWithPrefix("tfacclistview")
+	wantID := base64.StdEncoding.EncodeToString([]byte(dbName + "." + viewName))
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "mongodb_db_view" "test" {
+  db       = %[1]q
+  name     = %[2]q
+  view_on  = "source"
+  pipeline = "[]"
+}
+`, dbName, viewName),
+			},
+			{
+				Query: true,
+				Config: `
+provider "mongodb" {}
+
+list "mongodb_db_view" "test" {
+  provider = mongodb
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("mongodb_db_view.test", map[string]knownvalue.Check{
+						"id": knownvalue.StringExact(wantID),
+					}),
 				},
 			},
 		},
@@ -0,0 +1,245 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// dbUserRoleGrantResourceModel grants one role to a user without owning the
+// user's full role set (unlike the authoritative `role` blocks on db_user).
+type dbUserRoleGrantResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	AuthDatabase types.String `tfsdk:"auth_database"`
+	User         types.String `tfsdk:"user"`
+	Db           types.String `tfsdk:"db"`
+	Role         types.String `tfsdk:"role"`
+}
+
+type dbUserRoleGrantResource struct {
+	config *MongoDatabaseConfiguration
+}
+
+func newDBUserRoleGrantResource() resource.Resource { return &dbUserRoleGrantResource{} }
+
+var (
+	_ resource.Resource                = &dbUserRoleGrantResource{}
+	_ resource.ResourceWithConfigure   = &dbUserRoleGrantResource{}
+	_ resource.ResourceWithImportState = &dbUserRoleGrantResource{}
+	_ resource.ResourceWithIdentity    = &dbUserRoleGrantResource{}
+)
+
+func (r *dbUserRoleGrantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_user_role_grant"
+}
+
+func (r *dbUserRoleGrantResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
+
+func (r *dbUserRoleGrantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grants a single role to an existing user, leaving the user's other roles untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"auth_database": schema.StringAttribute{
+				Required:      true,
+				Description:   "Database the user is defined in.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"user": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the existing user to grant the role to.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"db": schema.StringAttribute{
+				Required:      true,
+				Description:   "Database the role is defined in.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"role": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the role to grant.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r *dbUserRoleGrantResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(*MongoDatabaseConfiguration)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *MongoDatabaseConfiguration, got %T", req.ProviderData))
+		return
+	}
+	r.config = config
+}
+
+func (r *dbUserRoleGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dbUserRoleGrantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	database := plan.AuthDatabase.ValueString()
+	userName := plan.User.ValueString()
+	role := Role{Role: plan.Role.ValueString(), Db: plan.Db.ValueString()}
+	if err := grantRolesToUser(client, userName, database, []Role{role}); err != nil {
+		resp.Diagnostics.AddError("Could not grant the role", err.Error())
+		return
+	}
+
+	id := dbUserRoleGrantId(database, role, userName)
+	var state dbUserRoleGrantResourceModel
+	if err := r.readGrantInto(client, id, &state); err != nil {
+		resp.Diagnostics.AddError("Error reading role grant after create", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dbUserRoleGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dbUserRoleGrantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	if err := r.readGrantInto(client, state.ID.ValueString(), &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading role grant", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called: every attribute forces replacement.
+func (r *dbUserRoleGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Unexpected update", "mongodb_db_user_role_grant does not support in-place updates")
+}
+
+func (r *dbUserRoleGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dbUserRoleGrantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	database, role, userName, err := dbUserRoleGrantParseId(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID mismatch", err.Error())
+		return
+	}
+	if err := revokeRolesFromUser(client, userName, database, []Role{role}); err != nil {
+		resp.Diagnostics.AddError("Could not revoke the role", err.Error())
+		return
+	}
+}
+
+func (r *dbUserRoleGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// readGrantInto looks the user up with usersInfo and checks only for the
+// granted role; any other roles the user holds are ignored.
+func (r *dbUserRoleGrantResource) readGrantInto(client *mongo.Client, id string, m *dbUserRoleGrantResourceModel) error {
+	database, role, userName, err := dbUserRoleGrantParseId(id)
+	if err != nil {
+		return err
+	}
+	result, err := getUser(client, userName, database)
+	if err != nil {
+		return err
+	}
+	if len(result.Users) == 0 {
+		return notFoundError{kind: "user"}
+	}
+
+	granted := false
+	for _, held := range result.Users[0].Roles {
+		if held.Role == role.Role && held.Db == role.Db {
+			granted = true
+			break
+		}
+	}
+	if !granted {
+		return notFoundError{kind: "role grant"}
+	}
+
+	m.ID = types.StringValue(id)
+	m.AuthDatabase = types.StringValue(database)
+	m.User = types.StringValue(userName)
+	m.Db = types.StringValue(role.Db)
+	m.Role = types.StringValue(role.Role)
+	return nil
+}
+
+// dbUserRoleGrantId encodes [auth_database, db, role, user] as a base64 JSON
+// array. Role and user names may both contain dots, so unlike the other IDs
+// the parts cannot be joined with ".".
+func dbUserRoleGrantId(database string, role Role, userName string) string {
+	encoded, _ := json.Marshal([]string{database, role.Db, role.Role, userName})
+	return base64.StdEncoding.EncodeToString(encoded)
+}
+
+// dbUserRoleGrantParseId decodes a dbUserRoleGrantId.
+func dbUserRoleGrantParseId(id string) (string, Role, string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return "", Role{}, "", fmt.Errorf("unexpected format of ID Error : %s", err)
+	}
+	var parts []string
+	if err := json.Unmarshal(decoded, &parts); err != nil || len(parts) != 4 {
+		return "", Role{}, "", fmt.Errorf("unexpected format of ID (%s), expected [auth_database, db, role, user]", decoded)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return "", Role{}, "", fmt.Errorf("invalid ID format: %s", decoded)
+		}
+	}
+	return parts[0], Role{Db: parts[1], Role: parts[2]}, parts[3], nil
+}
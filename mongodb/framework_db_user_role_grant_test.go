@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccMongoDBUserRoleGrant_Basic grants an extra role to a user managed
+// elsewhere and verifies the grant is additive: the user keeps the role from
+// its own `role` block, and destroying the grant revokes only the granted role.
+func TestAccMongoDBUserRoleGrant_Basic(t *testing.T) {
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+	userName := acctest.RandomWithPrefix("tf-acc-user")
+	resourceName := "mongodb_db_user_role_grant.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBUserRoleGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBUserRoleGrant(dbName, userName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "auth_database", dbName),
+					resource.TestCheckResourceAttr(resourceName, "user", userName),
+					resource.TestCheckResourceAttr(resourceName, "db", dbName),
+					resource.TestCheckResourceAttr(resourceName, "role", "dbAdmin"),
+					testAccCheckMongoDBUserHasRole(dbName, userName, "read"),
+					testAccCheckMongoDBUserHasRole(dbName, userName, "dbAdmin"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckMongoDBUserRoleGrantDestroy(s *terraform.State) error {
+	client, err := MongoClientInit(testAccMongoConfig())
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodb_db_user_role_grant" {
+			continue
+		}
+		database, role, userName, err := dbUserRoleGrantParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		result, err := getUser(client, userName, database)
+		if err != nil || len(result.Users) == 0 {
+			continue
+		}
+		for _, held := range result.Users[0].Roles {
+			if held.Role == role.Role && held.Db == role.Db {
+				return fmt.Errorf("role %s still granted to user %s", role.Role, userName)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccMongoDBUserRoleGrant(dbName, userName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_user" "test" {
+  auth_database = %[1]q
+  name          = %[2]q
+  password      = "tf-acc-password"
+
+  role {
+    db   = %[1]q
+    role = "read"
+  }
+
+  # The role set is authoritative; ignore roles granted by other resources.
+  lifecycle {
+    ignore_changes = [role]
+  }
+}
+
+resource "mongodb_db_user_role_grant" "test" {
+  auth_database = mongodb_db_user.test.auth_database
+  user          = mongodb_db_user.test.name
+  db            = %[1]q
+  role          = "dbAdmin"
+}
+`, dbName, userName)
+}
+
+func TestDBUserRoleGrantId(t *testing.T) {
+	cases := []struct {
+		name     string
+		database string
+		role     Role
+		userName string
+	}{
+		{"plain", "admin", Role{Db: "reporting", Role: "read"}, "app_user"},
+		{"dotted role", "admin", Role{Db: "reporting", Role: "read.only"}, "app_user"},
+		{"dotted role and user", "$external", Role{Db: "reporting", Role: "team.read"}, "arn:aws:iam::123456789012:user/ops.bot"},
+	}
+	for _, tc := range cases {
+		database, role, userName, err := dbUserRoleGrantParseId(dbUserRoleGrantId(tc.database, tc.role, tc.userName))
+		if err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+			continue
+		}
+		if database != tc.database || role != tc.role || userName != tc.userName {
+			t.Errorf("%s: round trip gave %q, %+v, %q", tc.name, database, role, userName)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type dbViewResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Db        types.String `tfsdk:"db"`
+	Name      types.String `tfsdk:"name"`
+	ViewOn    types.String `tfsdk:"view_on"`
+	Pipeline  types.String `tfsdk:"pipeline"`
+	Collation types.List   `tfsdk:"collation"`
+}
+
+type dbViewResource struct {
+	config *MongoDatabaseConfiguration
+}
+
+func newDBViewResource() resource.Resource { return &dbViewResource{} }
+
+var (
+	_ resource.Resource                   = &dbViewResource{}
+	_ resource.ResourceWithConfigure      = &dbViewResource{}
+	_ resource.ResourceWithImportState    = &dbViewResource{}
+	_ resource.ResourceWithIdentity       = &dbViewResource{}
+	_ resource.ResourceWithValidateConfig = &dbViewResource{}
+)
+
+func (r *dbViewResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_view"
+}
+
+func (r *dbViewResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
+
+func (r *dbViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A read-only view: the result of an aggregation pipeline over a collection or another view.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"db": schema.StringAttribute{
+				Required:      true,
+				Description:   "Database of the view and of its source.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "View name.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"view_on": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection or view in db the pipeline reads from. Changed in place with collMod.",
+			},
+			"pipeline": schema.StringAttribute{
+				Required:    true,
+				Description: "Aggregation pipeline as an Extended JSON array of stages, e.g. [{\"$match\": {...}}]. Changed in place with collMod.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"collation": collationBlock("Default collation of the view. A view does not inherit the collation of view_on."),
+		},
+	}
+}
+
+func (r *dbViewResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(*MongoDatabaseConfiguration)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *MongoDatabaseConfiguration, got %T", req.ProviderData))
+		return
+	}
+	r.config = config
+}
+
+func (r *dbViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dbViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+
+	stages, err := pipelineStages(plan.Pipeline.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Invalid pipeline", err.Error())
+		return
+	}
+	viewOptions := options.CreateView()
+	collation, diags := collationFromList(ctx, plan.Collation)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if collation != nil {
+		viewOptions.SetCollation(collation)
+	}
+
+	db := plan.Db.ValueString()
+	viewName := plan.Name.ValueString()
+	if err := client.Database(db).CreateView(ctx, viewName, plan.ViewOn.ValueString(), stages, viewOptions); err != nil {
+		resp.Diagnostics.AddError("Could not create the view", err.Error())
+		return
+	}
+
+	id := base64.StdEncoding.EncodeToString([]byte(db + "." + viewName))
+	state := plan
+	if err := readViewInto(client, id, &state); err != nil {
+		resp.Diagnostics.AddError("Error reading view after create", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dbViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dbViewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+
+	if err := readViewInto(client, state.ID.ValueString(), &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading view", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update redefines the view with collMod. Only view_on and pipeline can
+// change; the other attributes force a new view.
+func (r *dbViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dbViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+
+	db, viewName, err := resourceDatabaseCollectionParseId(plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID mismatch", err.Error())
+		return
+	}
+	stages, err := pipelineStages(plan.Pipeline.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Invalid pipeline", err.Error())
+		return
+	}
+	if err := client.Database(db).RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: viewName},
+		{Key: "viewOn", Value: plan.ViewOn.ValueString()},
+		{Key: "pipeline", Value: stages},
+	}).Err(); err != nil {
+		resp.Diagnostics.AddError("Could not update the view", err.Error())
+		return
+	}
+
+	state := plan
+	if err := readViewInto(client, plan.ID.ValueString(), &state); err != nil {
+		resp.Diagnostics.AddError("Error reading view after update", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dbViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dbViewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+
+	db, viewName, err := resourceDatabaseCollectionParseId(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID mismatch", err.Error())
+		return
+	}
+	// Dropping a view leaves view_on and its documents untouched.
+	if err := client.Database(db).Collection(viewName).Drop(ctx); err != nil {
+		resp.Diagnostics.AddError("Could not delete the view", err.Error())
+		return
+	}
+}
+
+func (r *dbViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ValidateConfig checks that pipeline is an array of stage documents.
+func (r *dbViewResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var pipeline types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pipeline"), &pipeline)...)
+	if resp.Diagnostics.HasError() || pipeline.IsNull() || pipeline.IsUnknown() {
+		return
+	}
+	if _, err := pipelineStages(pipeline.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Invalid pipeline", err.Error())
+	}
+}
+
+// readViewInto populates id, db, name, view_on, pipeline and collation from
+// listCollections. A collection with the same name is not a view and reads
+// as not found.
+func readViewInto(client *mongo.Client, id string, m *dbViewResourceModel) error {
+	db, viewName, err := resourceDatabaseCollectionParseId(id)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := client.Database(db).ListCollections(context.Background(), bson.D{
+		{Key: "name", Value: viewName},
+		{Key: "type", Value: "view"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list collections : %s", err)
+	}
+	defer cursor.Close(context.Background())
+	if !cursor.Next(context.Background()) {
+		return notFoundError{kind: "view"}
+	}
+	var spec mongo.CollectionSpecification
+	if err := cursor.Decode(&spec); err != nil {
+		return fmt.Errorf("failed to decode view specification : %s", err)
+	}
+
+	m.ID = types.StringValue(id)
+	m.Db = types.StringValue(db)
+	m.Name = types.StringValue(viewName)
+	viewOn, _ := spec.Options.Lookup("viewOn").StringValueOK()
+	m.ViewOn = types.StringValue(viewOn)
+
+	pipeline, err := pipelineJSON(spec.Options.Lookup("pipeline"))
+	if err != nil {
+		return err
+	}
+	// Keep the configured spelling of an identical pipeline.
+	if !samePipeline(m.Pipeline.ValueString(), pipeline) {
+		m.Pipeline = types.StringValue(pipeline)
+	}
+
+	collationRaw, _ := spec.Options.Lookup("collation").DocumentOK()
+	collation, err := decodeCollation(collationRaw)
+	if err != nil {
+		return err
+	}
+	m.Collation = collationList(collation, m.Collation)
+	return nil
+}
+
+// pipelineStages parses an Extended JSON array of aggregation stages. ""
+// is an empty pipeline.
+func pipelineStages(pipeline string) (bson.A, error) {
+	if pipeline == "" {
+		return bson.A{}, nil
+	}
+	// Extended JSON must be a document at the top level.
+	var doc struct {
+		Pipeline bson.A `bson:"pipeline"`
+	}
+	if err := bson.UnmarshalExtJSON([]byte(`{"pipeline": `+pipeline+`}`), false, &doc); err != nil {
+		return nil, fmt.Errorf("pipeline is not a valid Extended JSON array : %s", err)
+	}
+	for i, stage := range doc.Pipeline {
+		if _, ok := stage.(bson.D); !ok {
+			return nil, fmt.Errorf("pipeline stage %d is not a document", i)
+		}
+	}
+	if doc.Pipeline == nil {
+		return bson.A{}, nil
+	}
+	return doc.Pipeline, nil
+}
+
+// pipelineJSON prints a pipeline read from the server as relaxed Extended
+// JSON.
+func pipelineJSON(value bson.RawValue) (string, error) {
+	if value.Type == 0 {
+		return "[]", nil
+	}
+	doc, err := bson.MarshalExtJSON(bson.D{{Key: "pipeline", Value: value}}, false, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pipeline : %s", err)
+	}
+	var wrapper struct {
+		Pipeline json.RawMessage `json:"pipeline"`
+	}
+	if err := json.Unmarshal(doc, &wrapper); err != nil {
+		return "", fmt.Errorf("failed to read pipeline : %s", err)
+	}
+	return string(wrapper.Pipeline), nil
+}
+
+// samePipeline reports whether two Extended JSON pipelines have the same
+// stages, ignoring whitespace and number spelling.
+func samePipeline(a, b string) bool {
+	if a == "" {
+		a = "[]"
+	}
+	if b == "" {
+		b = "[]"
+	}
+	return sameExtJSONDocument(`{"pipeline": `+a+`}`, `{"pipeline": `+b+`}`)
+}
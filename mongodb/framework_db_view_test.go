@@ -0,0 +1,142 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TestAccMongoDBView_Basic creates a view over a collection, imports it, and
+// changes its pipeline in place.
+func TestAccMongoDBView_Basic(t *testing.T) {
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+	viewName := acctest.RandomWithPrefix("tf-acc-view")
+	resourceName := "mongodb_db_view.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBView(dbName, viewName, `[{"$project": {"ssn": 0}}]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "view_on", "people"),
+					resource.TestCheckResourceAttr(resourceName, "pipeline", `[{"$project": {"ssn": 0}}]`),
+					resource.TestCheckResourceAttr(resourceName, "collation.0.locale", "en"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The server prints the pipeline in its own spelling.
+				ImportStateVerifyIgnore: []string{"pipeline"},
+			},
+			{
+				Config: testAccMongoDBView(dbName, viewName, `[{"$match": {"active": true}}, {"$project": {"ssn": 0}}]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "pipeline", `[{"$match": {"active": true}}, {"$project": {"ssn": 0}}]`),
+			},
+		},
+	})
+}
+
+func testAccMongoDBView(dbName, viewName, pipeline string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "people" {
+  db                  = %[1]q
+  name                = "people"
+  deletion_protection = false
+}
+
+resource "mongodb_db_view" "test" {
+  db       = mongodb_db_collection.people.db
+  name     = %[2]q
+  view_on  = mongodb_db_collection.people.name
+  pipeline = %[3]q
+
+  collation {
+    locale = "en"
+  }
+}
+`, dbName, viewName, pipeline)
+}
+
+func testAccCheckMongoDBViewDestroy(s *terraform.State) error {
+	client, err := MongoClientInit(testAccMongoConfig())
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodb_db_view" {
+			continue
+		}
+		db, viewName, err := resourceDatabaseCollectionParseId(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing ID: %s", err)
+		}
+		names, err := client.Database(db).ListCollectionNames(context.Background(), bson.D{{Key: "name", Value: viewName}})
+		if err != nil {
+			return fmt.Errorf("error listing collections: %s", err)
+		}
+		if len(names) > 0 {
+			return fmt.Errorf("view %s still exists in database %s", viewName, db)
+		}
+	}
+	return nil
+}
+
+func TestPipelineStages(t *testing.T) {
+	cases := []struct {
+		pipeline string
+		stages   int
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"[]", 0, false},
+		{`[{"$match": {"a": 1}}, {"$project": {"b": 0}}]`, 2, false},
+		{`{"$match": {}}`, 0, true},
+		{`[1]`, 0, true},
+		{`[{"$match": `, 0, true},
+	}
+	for _, tc := range cases {
+		stages, err := pipelineStages(tc.pipeline)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("pipelineStages(%q) error = %v, wantErr %v", tc.pipeline, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && len(stages) != tc.stages {
+			t.Errorf("pipelineStages(%q) = %d stages, want %d", tc.pipeline, len(stages), tc.stages)
+		}
+	}
+}
+
+func TestPipelineJSON(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{{Key: "pipeline", Value: bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "n", Value: int32(1)}}}},
+	}}})
+	got, err := pipelineJSON(bson.Raw(raw).Lookup("pipeline"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"$match":{"n":1}}]`; got != want {
+		t.Errorf("pipelineJSON = %s, want %s", got, want)
+	}
+	if !samePipeline(got, `[ {"$match": {"n": {"$numberInt": "1"}}} ]`) {
+		t.Errorf("samePipeline(%s) = false for the same stages", got)
+	}
+	if samePipeline(got, `[{"$match": {"n": 2}}]`) {
+		t.Errorf("samePipeline(%s) = true for different stages", got)
+	}
+}
@@ -0,0 +1,232 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var indexStatsObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"collection": types.StringType,
+	"name":       types.StringType,
+	"keys":       types.ListType{ElemType: dbIndexKeyObjectType},
+	"ops":        types.Int64Type,
+	"since":      types.StringType,
+	"host":       types.StringType,
+	"shard":      types.StringType,
+	"building":   types.BoolType,
+}}
+
+type indexStatsDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Db         types.String `tfsdk:"db"`
+	Collection types.String `tfsdk:"collection"`
+	Indexes    types.List   `tfsdk:"indexes"`
+}
+
+type indexStatsModel struct {
+	Collection types.String `tfsdk:"collection"`
+	Name       types.String `tfsdk:"name"`
+	Keys       types.List   `tfsdk:"keys"`
+	Ops        types.Int64  `tfsdk:"ops"`
+	Since      types.String `tfsdk:"since"`
+	Host       types.String `tfsdk:"host"`
+	Shard      types.String `tfsdk:"shard"`
+	Building   types.Bool   `tfsdk:"building"`
+}
+
+// indexStatsDoc is one $indexStats result. Through mongos there is one per
+// index per shard.
+type indexStatsDoc struct {
+	Name     string `bson:"name"`
+	Key      bson.D `bson:"key"`
+	Host     string `bson:"host"`
+	Shard    string `bson:"shard"`
+	Building bool   `bson:"building"`
+	Accesses struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+	Spec struct {
+		Weights bson.D `bson:"weights"`
+	} `bson:"spec"`
+}
+
+type indexStatsDataSource struct {
+	config *MongoDatabaseConfiguration
+}
+
+func newIndexStatsDataSource() datasource.DataSource { return &indexStatsDataSource{} }
+
+var (
+	_ datasource.DataSource              = &indexStatsDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexStatsDataSource{}
+)
+
+func (d *indexStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index_stats"
+}
+
+func (d *indexStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Index usage statistics from $indexStats, for one collection or every collection in a database.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"db": schema.StringAttribute{
+				Required:    true,
+				Description: "Database to read index statistics from.",
+			},
+			"collection": schema.StringAttribute{
+				Optional:    true,
+				Description: "Collection to read index statistics from. When unset, every collection in db is read; views and system collections are skipped.",
+			},
+			"indexes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "One entry per index (per index and shard on a sharded cluster), sorted by collection, name and shard.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"collection": schema.StringAttribute{Computed: true, Description: "Collection the index belongs to."},
+						"name":       schema.StringAttribute{Computed: true, Description: "Index name."},
+						"keys": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Index keys in order, in the form used by mongodb_db_index.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"field": schema.StringAttribute{Computed: true},
+									"value": schema.StringAttribute{Computed: true},
+								},
+							},
+						},
+						"ops":      schema.Int64Attribute{Computed: true, Description: "Number of operations that used the index since since."},
+						"since":    schema.StringAttribute{Computed: true, Description: "RFC 3339 time the counter started: the last server restart or index creation."},
+						"host":     schema.StringAttribute{Computed: true, Description: "Host:port of the mongod the statistics come from."},
+						"shard":    schema.StringAttribute{Computed: true, Description: "Shard the statistics come from; empty outside a sharded cluster."},
+						"building": schema.BoolAttribute{Computed: true, Description: "Whether the index is still being built."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *indexStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(*MongoDatabaseConfiguration)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *MongoDatabaseConfiguration, got %T", req.ProviderData))
+		return
+	}
+	d.config = config
+}
+
+func (d *indexStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data indexStatsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(d.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to database", err.Error())
+		return
+	}
+
+	db := data.Db.ValueString()
+	collections := []string{data.Collection.ValueString()}
+	id := db + "." + data.Collection.ValueString()
+	if data.Collection.IsNull() {
+		// $indexStats cannot run on a view.
+		collections, err = client.Database(db).ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list collections", err.Error())
+			return
+		}
+		id = db
+	}
+
+	var indexes []indexStatsModel
+	for _, collectionName := range collections {
+		if strings.HasPrefix(collectionName, "system.") {
+			continue
+		}
+		docs, err := collectionIndexStats(ctx, client, db, collectionName)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read index statistics", err.Error())
+			return
+		}
+		indexes = append(indexes, indexStatsModels(collectionName, docs)...)
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := indexes[i], indexes[j]
+		if a.Collection.ValueString() != b.Collection.ValueString() {
+			return a.Collection.ValueString() < b.Collection.ValueString()
+		}
+		if a.Name.ValueString() != b.Name.ValueString() {
+			return a.Name.ValueString() < b.Name.ValueString()
+		}
+		return a.Shard.ValueString() < b.Shard.ValueString()
+	})
+
+	list, diags := types.ListValueFrom(ctx, indexStatsObjectType, indexes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(base64.StdEncoding.EncodeToString([]byte(id)))
+	data.Indexes = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// collectionIndexStats runs $indexStats on one collection. Reading another
+// user's statistics needs the indexStats privilege.
+func collectionIndexStats(ctx context.Context, client *mongo.Client, db, collectionName string) ([]indexStatsDoc, error) {
+	cursor, err := client.Database(db).Collection(collectionName).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$indexStats", Value: bson.D{}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run $indexStats on %s.%s : %s", db, collectionName, err)
+	}
+	var docs []indexStatsDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to read $indexStats on %s.%s : %s", db, collectionName, err)
+	}
+	return docs, nil
+}
+
+// indexStatsModels converts $indexStats results to data source entries. Text
+// index keys are expanded the way mongodb_db_index reads them.
+func indexStatsModels(collectionName string, docs []indexStatsDoc) []indexStatsModel {
+	models := make([]indexStatsModel, 0, len(docs))
+	for _, doc := range docs {
+		var keyValues []attr.Value
+		for _, k := range indexKeysFromSpec(doc.Key, doc.Spec.Weights, nil) {
+			keyValues = append(keyValues, mustIndexKeyObject(k.Field.ValueString(), k.Value.ValueString()))
+		}
+		models = append(models, indexStatsModel{
+			Collection: types.StringValue(collectionName),
+			Name:       types.StringValue(doc.Name),
+			Keys:       types.ListValueMust(dbIndexKeyObjectType, keyValues),
+			Ops:        types.Int64Value(doc.Accesses.Ops),
+			Since:      types.StringValue(doc.Accesses.Since.UTC().Format(time.RFC3339)),
+			Host:       types.StringValue(doc.Host),
+			Shard:      types.StringValue(doc.Shard),
+			Building:   types.BoolValue(doc.Building),
+		})
+	}
+	return models
+}
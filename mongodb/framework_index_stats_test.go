@@ -0,0 +1,98 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TestAccMongoDBIndexStats_Basic reads the statistics of a fresh collection:
+// both its indexes are listed, and neither has been used yet.
+func TestAccMongoDBIndexStats_Basic(t *testing.T) {
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+	collectionName := acctest.RandomWithPrefix("tf-acc-coll")
+	dataSourceName := "data.mongodb_index_stats.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexStats(dbName, collectionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "indexes.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "indexes.0.name", "_id_"),
+					resource.TestCheckResourceAttr(dataSourceName, "indexes.1.name", "email_1"),
+					resource.TestCheckResourceAttr(dataSourceName, "indexes.1.collection", collectionName),
+					resource.TestCheckResourceAttr(dataSourceName, "indexes.1.keys.0.field", "email"),
+					resource.TestCheckResourceAttr(dataSourceName, "indexes.1.ops", "0"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "indexes.1.since"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexStats(dbName, collectionName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_index" "test" {
+  db         = %[1]q
+  collection = %[2]q
+  name       = "email_1"
+  keys {
+    field = "email"
+    value = "1"
+  }
+}
+
+data "mongodb_index_stats" "test" {
+  db         = mongodb_db_index.test.db
+  collection = mongodb_db_index.test.collection
+}
+`, dbName, collectionName)
+}
+
+func TestIndexStatsModels(t *testing.T) {
+	doc := indexStatsDoc{
+		Name:  "title_text_body_text",
+		Key:   bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+		Host:  "db0:27017",
+		Shard: "shard-a",
+	}
+	doc.Accesses.Ops = 42
+	doc.Accesses.Since = time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	doc.Spec.Weights = bson.D{{Key: "body", Value: int32(1)}, {Key: "title", Value: int32(10)}}
+
+	models := indexStatsModels("articles", []indexStatsDoc{doc})
+	if len(models) != 1 {
+		t.Fatalf("got %d entries, want 1", len(models))
+	}
+	m := models[0]
+	if m.Collection.ValueString() != "articles" || m.Name.ValueString() != "title_text_body_text" {
+		t.Errorf("unexpected index: %s.%s", m.Collection, m.Name)
+	}
+	if m.Ops.ValueInt64() != 42 || m.Shard.ValueString() != "shard-a" || m.Building.ValueBool() {
+		t.Errorf("unexpected stats: ops=%s shard=%s building=%s", m.Ops, m.Shard, m.Building)
+	}
+	if got, want := m.Since.ValueString(), "2026-03-01T11:00:00Z"; got != want {
+		t.Errorf("since: got %s, want %s", got, want)
+	}
+
+	var keys []dbIndexKeyModel
+	if diags := m.Keys.ElementsAs(context.Background(), &keys, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var got []string
+	for _, k := range keys {
+		got = append(got, k.Field.ValueString()+"="+k.Value.ValueString())
+	}
+	if want := []string{"body=text", "title=text"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keys: got %v, want %v", got, want)
+	}
+}
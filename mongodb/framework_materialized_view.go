@@ -0,0 +1,353 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// materializedViewResourceModel is an on-demand materialized view: the
+// output of pipeline over source, merged into target. The aggregation runs
+// on create and whenever the definition or triggers change, never on
+// refresh.
+type materializedViewResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Db             types.String `tfsdk:"db"`
+	Source         types.String `tfsdk:"source"`
+	Pipeline       types.String `tfsdk:"pipeline"`
+	Target         types.String `tfsdk:"target"`
+	WhenMatched    types.String `tfsdk:"when_matched"`
+	WhenNotMatched types.String `tfsdk:"when_not_matched"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	Timeout        types.Int64  `tfsdk:"timeout"`
+	LastRefreshed  types.String `tfsdk:"last_refreshed"`
+	DocumentCount  types.Int64  `tfsdk:"document_count"`
+}
+
+type materializedViewResource struct {
+	config *MongoDatabaseConfiguration
+}
+
+func newMaterializedViewResource() resource.Resource { return &materializedViewResource{} }
+
+var (
+	_ resource.Resource                   = &materializedViewResource{}
+	_ resource.ResourceWithConfigure      = &materializedViewResource{}
+	_ resource.ResourceWithImportState    = &materializedViewResource{}
+	_ resource.ResourceWithIdentity       = &materializedViewResource{}
+	_ resource.ResourceWithModifyPlan     = &materializedViewResource{}
+	_ resource.ResourceWithValidateConfig = &materializedViewResource{}
+)
+
+func (r *materializedViewResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_materialized_view"
+}
+
+func (r *materializedViewResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
+
+func (r *materializedViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs an aggregation pipeline over a collection and $merges the result into a target collection on create and whenever the pipeline or triggers change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"db": schema.StringAttribute{
+				Required:      true,
+				Description:   "Database of the source and target collections.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"source": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection the pipeline reads from.",
+			},
+			"pipeline": schema.StringAttribute{
+				Required:    true,
+				Description: "Aggregation pipeline as an Extended JSON array of stages, without a final $merge or $out stage.",
+			},
+			"target": schema.StringAttribute{
+				Required:      true,
+				Description:   "Collection the results are merged into. Created by the first refresh if it does not exist.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"when_matched": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("replace"),
+				Description: "$merge whenMatched: what to do with a result whose _id is already in target: \"replace\", \"keepExisting\", \"merge\" or \"fail\".",
+				Validators:  []validator.String{stringvalidator.OneOf("replace", "keepExisting", "merge", "fail")},
+			},
+			"when_not_matched": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("insert"),
+				Description: "$merge whenNotMatched: what to do with a result whose _id is not in target: \"insert\", \"discard\" or \"fail\".",
+				Validators:  []validator.String{stringvalidator.OneOf("insert", "discard", "fail")},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values; changing any of them runs the aggregation again.",
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(300),
+				Description: "Seconds to wait for the aggregation.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"last_refreshed": schema.StringAttribute{
+				Computed:      true,
+				Description:   "RFC 3339 time the aggregation last completed.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"document_count": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "Number of documents in target after the last refresh.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r *materializedViewResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(*MongoDatabaseConfiguration)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *MongoDatabaseConfiguration, got %T", req.ProviderData))
+		return
+	}
+	r.config = config
+}
+
+func (r *materializedViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan materializedViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(base64.StdEncoding.EncodeToString([]byte(plan.Db.ValueString() + "." + plan.Target.ValueString())))
+	if err := refreshMaterializedView(client, &plan); err != nil {
+		resp.Diagnostics.AddError("Could not refresh the materialized view", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: plan.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read only checks that target still exists: its documents are data, not
+// configuration, and are only rewritten by a refresh.
+func (r *materializedViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state materializedViewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := MongoClientInit(r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error connecting to db", err.Error())
+		return
+	}
+
+	if err := readMaterializedViewInto(client, &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading materialized view", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: state.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *materializedViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state materializedViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if materializedViewNeedsRefresh(plan, state) {
+		client, err := MongoClientInit(r.config)
+		if err != nil {
+			resp.Diagnostics.AddError("Error connecting to db", err.Error())
+			return
+		}
+		if err := refreshMaterializedView(client, &plan); err != nil {
+			resp.Diagnostics.AddError("Could not refresh the materialized view", err.Error())
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dbUserIdentityModel{ID: plan.ID})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the resource from state. The target collection and its
+// documents are left in place; manage it with mongodb_db_collection to have
+// it dropped.
+func (r *materializedViewResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ImportState takes the base64 db.target ID. The pipeline is not stored on
+// the server, so source and pipeline come from the configuration and the
+// first apply after import runs the aggregation.
+func (r *materializedViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan marks last_refreshed and document_count unknown when the apply
+// will run the aggregation again.
+func (r *materializedViewResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return // create or destroy
+	}
+	var plan, state materializedViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !materializedViewNeedsRefresh(plan, state) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_refreshed"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("document_count"), types.Int64Unknown())...)
+}
+
+// ValidateConfig checks that pipeline is an array of stages that does not
+// already write its output somewhere.
+func (r *materializedViewResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var pipeline types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pipeline"), &pipeline)...)
+	if resp.Diagnostics.HasError() || pipeline.IsNull() || pipeline.IsUnknown() {
+		return
+	}
+	stages, err := pipelineStages(pipeline.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Invalid pipeline", err.Error())
+		return
+	}
+	for _, stage := range stages {
+		for _, e := range stage.(bson.D) {
+			if e.Key == "$merge" || e.Key == "$out" {
+				resp.Diagnostics.AddAttributeError(path.Root("pipeline"), "Invalid pipeline",
+					fmt.Sprintf("pipeline must not contain a %s stage; the results are merged into target.", e.Key))
+			}
+		}
+	}
+}
+
+// materializedViewNeedsRefresh reports whether the change from state to plan
+// affects the target's contents. An unknown value counts as a change.
+func materializedViewNeedsRefresh(plan, state materializedViewResourceModel) bool {
+	return !plan.Source.Equal(state.Source) ||
+		!samePipeline(plan.Pipeline.ValueString(), state.Pipeline.ValueString()) || plan.Pipeline.IsUnknown() ||
+		!plan.WhenMatched.Equal(state.WhenMatched) ||
+		!plan.WhenNotMatched.Equal(state.WhenNotMatched) ||
+		!plan.Triggers.Equal(state.Triggers)
+}
+
+// readMaterializedViewInto checks that the target collection named by the ID
+// exists. It fills in what an import leaves unset: db and target from the
+// ID, document_count from the target, and the defaults of the client-side
+// settings. Source, pipeline and triggers are left to the configuration.
+func readMaterializedViewInto(client *mongo.Client, m *materializedViewResourceModel) error {
+	db, target, err := resourceDatabaseCollectionParseId(m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	names, err := client.Database(db).ListCollectionNames(context.Background(), bson.D{{Key: "name", Value: target}})
+	if err != nil {
+		return fmt.Errorf("failed to list collections : %w", err)
+	}
+	if len(names) == 0 {
+		return notFoundError{kind: "materialized view target"}
+	}
+
+	m.Db = types.StringValue(db)
+	m.Target = types.StringValue(target)
+	if m.DocumentCount.IsNull() {
+		count, err := client.Database(db).Collection(target).CountDocuments(context.Background(), bson.D{})
+		if err != nil {
+			return fmt.Errorf("failed to count documents in %s.%s : %w", db, target, err)
+		}
+		m.DocumentCount = types.Int64Value(count)
+	}
+	if m.WhenMatched.IsNull() {
+		m.WhenMatched = types.StringValue("replace")
+	}
+	if m.WhenNotMatched.IsNull() {
+		m.WhenNotMatched = types.StringValue("insert")
+	}
+	if m.Timeout.IsNull() {
+		m.Timeout = types.Int64Value(300)
+	}
+	return nil
+}
+
+// refreshMaterializedView runs the pipeline with a final $merge into the
+// target and records when it finished and how many documents target holds.
+func refreshMaterializedView(client *mongo.Client, m *materializedViewResourceModel) error {
+	stages, err := pipelineStages(m.Pipeline.ValueString())
+	if err != nil {
+		return err
+	}
+	stages = append(stages, bson.D{{Key: "$merge", Value: bson.D{
+		{Key: "into", Value: m.Target.ValueString()},
+		{Key: "whenMatched", Value: m.WhenMatched.ValueString()},
+		{Key: "whenNotMatched", Value: m.WhenNotMatched.ValueString()},
+	}}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.Timeout.ValueInt64())*time.Second)
+	defer cancel()
+	dbClient := client.Database(m.Db.ValueString())
+	cursor, err := dbClient.Collection(m.Source.ValueString()).Aggregate(ctx, stages)
+	if err != nil {
+		return fmt.Errorf("failed to run the pipeline on %s.%s : %s", m.Db.ValueString(), m.Source.ValueString(), err)
+	}
+	// $merge returns no documents; draining the cursor surfaces any error.
+	if err := cursor.All(ctx, &[]bson.Raw{}); err != nil {
+		return fmt.Errorf("failed to run the pipeline on %s.%s : %s", m.Db.ValueString(), m.Source.ValueString(), err)
+	}
+
+	count, err := dbClient.Collection(m.Target.ValueString()).CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return fmt.Errorf("failed to count documents in %s.%s : %s", m.Db.ValueString(), m.Target.ValueString(), err)
+	}
+	m.LastRefreshed = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	m.DocumentCount = types.Int64Value(count)
+	return nil
+}
@@ -0,0 +1,132 @@
+package mongodb
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TestAccMongoDBMaterializedView_Basic merges a grouped pipeline into a
+// target collection, then changes a trigger to run it again after more
+// source documents arrive. It then imports the resource, and checks that a
+// dropped target is planned for creation again.
+func TestAccMongoDBMaterializedView_Basic(t *testing.T) {
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_materialized_view.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMongoDBMaterializedView(dbName, `[{"$out": "elsewhere"}]`, "1"),
+				ExpectError: regexp.MustCompile(`pipeline must not contain a \$out stage`),
+			},
+			{
+				PreConfig: func() {
+					testAccMongoDBInsertOrders(t, dbName, "eu", "us")
+				},
+				Config: testAccMongoDBMaterializedView(dbName, `[{"$group": {"_id": "$region", "orders": {"$sum": 1}}}]`, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "document_count", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_refreshed"),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccMongoDBInsertOrders(t, dbName, "apac")
+				},
+				Config: testAccMongoDBMaterializedView(dbName, `[{"$group": {"_id": "$region", "orders": {"$sum": 1}}}]`, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("document_count")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "document_count", "3"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "pipeline", "triggers", "last_refreshed"},
+			},
+			{
+				Config: testAccMongoDBMaterializedView(dbName, `[{"$group": {"_id": "$region", "orders": {"$sum": 1}}}]`, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionDisappears(dbName, "orders_by_region"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccMongoDBMaterializedView(dbName, pipeline, version string) string {
+	return fmt.Sprintf(`
+resource "mongodb_materialized_view" "test" {
+  db       = %[1]q
+  source   = "orders"
+  target   = "orders_by_region"
+  pipeline = %[2]q
+
+  triggers = {
+    version = %[3]q
+  }
+}
+`, dbName, pipeline, version)
+}
+
+func testAccMongoDBInsertOrders(t *testing.T, dbName string, regions ...string) {
+	docs := make([]bson.D, 0, len(regions))
+	for _, region := range regions {
+		docs = append(docs, bson.D{{Key: "region", Value: region}})
+	}
+	if err := testAccMongoDBInsertDocuments(dbName, "orders", docs...)(nil); err != nil {
+		t.Fatalf("error inserting orders: %s", err)
+	}
+}
+
+func TestMaterializedViewNeedsRefresh(t *testing.T) {
+	base := materializedViewResourceModel{
+		Source:         types.StringValue("orders"),
+		Pipeline:       types.StringValue(`[{"$match": {"a": 1}}]`),
+		WhenMatched:    types.StringValue("replace"),
+		WhenNotMatched: types.StringValue("insert"),
+		Triggers:       types.MapValueMust(types.StringType, map[string]attr.Value{"v": types.StringValue("1")}),
+		Timeout:        types.Int64Value(300),
+	}
+	cases := []struct {
+		name   string
+		change func(m *materializedViewResourceModel)
+		want   bool
+	}{
+		{"no change", func(m *materializedViewResourceModel) {}, false},
+		{"timeout only", func(m *materializedViewResourceModel) { m.Timeout = types.Int64Value(60) }, false},
+		{"pipeline respelled", func(m *materializedViewResourceModel) {
+			m.Pipeline = types.StringValue(`[{"$match":{"a":{"$numberInt":"1"}}}]`)
+		}, false},
+		{"pipeline changed", func(m *materializedViewResourceModel) { m.Pipeline = types.StringValue(`[{"$match": {"a": 2}}]`) }, true},
+		{"pipeline unknown", func(m *materializedViewResourceModel) { m.Pipeline = types.StringUnknown() }, true},
+		{"source changed", func(m *materializedViewResourceModel) { m.Source = types.StringValue("returns") }, true},
+		{"merge mode changed", func(m *materializedViewResourceModel) { m.WhenMatched = types.StringValue("merge") }, true},
+		{"trigger changed", func(m *materializedViewResourceModel) {
+			m.Triggers = types.MapValueMust(types.StringType, map[string]attr.Value{"v": types.StringValue("2")})
+		}, true},
+	}
+	for _, tc := range cases {
+		plan := base
+		tc.change(&plan)
+		if got := materializedViewNeedsRefresh(plan, base); got != tc.want {
+			t.Errorf("%s: materializedViewNeedsRefresh = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
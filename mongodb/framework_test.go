@@ -31,11 +31,14 @@ func TestMuxProviderSchema(t *testing.T) {
 			t.Errorf("provider schema diagnostic: %s — %s", d.Summary, d.Detail)
 		}
 	}
-	for _, typ := range []string{"mongodb_db_user", "mongodb_db_role", "mongodb_db_collection", "mongodb_db_index"} {
+	for _, typ := range []string{"mongodb_db_user", "mongodb_db_role", "mongodb_db_collection", "mongodb_db_index", "mongodb_db_user_role_grant", "mongodb_db_role_privilege", "mongodb_collection_indexes", "mongodb_db_view", "mongodb_materialized_view"} {
 		if _, ok := resp.ResourceSchemas[typ]; !ok {
 			t.Errorf("%s not present in muxed provider schema", typ)
 		}
 	}
+	if _, ok := resp.DataSourceSchemas["mongodb_index_stats"]; !ok {
+		t.Errorf("mongodb_index_stats not present in muxed provider schema")
+	}
 }
 
 // TestMuxListResources verifies the mux server actually serves the framework
@@ -55,7 +58,7 @@ func TestMuxListResources(t *testing.T) {
 			t.Errorf("provider schema diagnostic: %s — %s", d.Summary, d.Detail)
 		}
 	}
-	for _, typ := range []string{"mongodb_db_user", "mongodb_db_role", "mongodb_db_collection", "mongodb_db_index"} {
+	for _, typ := range []string{"mongodb_db_user", "mongodb_db_role", "mongodb_db_collection", "mongodb_db_index", "mongodb_db_view"} {
 		if _, ok := resp.ListResourceSchemas[typ]; !ok {
 			got := make([]string, 0, len(resp.ListResourceSchemas))
 			for k := range resp.ListResourceSchemas {
@@ -80,12 +83,25 @@ func TestResourceStateShapeUnchanged(t *testing.T) {
 		fw   resource.Resource
 		// newAttrs are framework attributes added since the SDKv2 schema
 		// (additive, not a state-compat concern) and excluded from the check.
+		// Attributes of a nested block are named block.attribute.
 		newAttrs map[string]bool
 	}{
 		{"mongodb_db_user", resourceDatabaseUser(), newDBUserResource(), map[string]bool{"password_wo": true, "password_wo_version": true, "authentication_restriction": true}},
-		{"mongodb_db_role", resourceDatabaseRole(), newDBRoleResource(), map[string]bool{"authentication_restriction": true}},
-		{"mongodb_db_collection", resourceDatabaseCollection(), newDBCollectionResource(), nil},
-		{"mongodb_db_index", resourceDatabaseIndex(), newDBIndexResource(), nil},
+		{"mongodb_db_role", resourceDatabaseRole(), newDBRoleResource(), map[string]bool{
+			"authentication_restriction": true, "privilege.cluster": true, "privilege.any_resource": true,
+		}},
+		{"mongodb_db_collection", resourceDatabaseCollection(), newDBCollectionResource(), map[string]bool{
+			"collation": true, "validator": true, "validation_level": true, "validation_action": true,
+			"capped": true, "size": true, "max": true, "timeseries": true, "expire_after_seconds": true,
+			"clustered_index": true, "previous_db": true, "previous_name": true, "storage_engine": true,
+		}},
+		{"mongodb_db_index", resourceDatabaseIndex(), newDBIndexResource(), map[string]bool{
+			"unique": true, "sparse": true, "expire_after_seconds": true, "collation": true,
+			"weights": true, "default_language": true, "language_override": true, "text_index_version": true,
+			"bits": true, "min": true, "max": true, "sphere_index_version": true,
+			"wildcard_projection": true, "commit_quorum": true, "wait_for_build": true, "adopt_existing": true,
+			"storage_engine": true,
+		}},
 	}
 
 	for _, tc := range cases {
@@ -103,7 +119,7 @@ func TestResourceStateShapeUnchanged(t *testing.T) {
 			}
 			fwKinds := map[string]string{}
 			for name, at := range fwObj.AttributeTypes {
-				fwKinds[name] = tfKind(at)
+				fwKinds[name] = tfKind(withoutNewAttrs(name, at, tc.newAttrs))
 			}
 
 			for name, kind := range sdkKinds {
@@ -143,6 +159,32 @@ func ctyKind(t cty.Type) string {
 	return t.FriendlyName()
 }
 
+// withoutNewAttrs drops the newAttrs of a nested block from the element type
+// of the block's set or list.
+func withoutNewAttrs(block string, t tftypes.Type, newAttrs map[string]bool) tftypes.Type {
+	var elem tftypes.Type
+	switch {
+	case t.Is(tftypes.Set{}):
+		elem = t.(tftypes.Set).ElementType
+	case t.Is(tftypes.List{}):
+		elem = t.(tftypes.List).ElementType
+	}
+	obj, ok := elem.(tftypes.Object)
+	if !ok {
+		return t
+	}
+	attrs := map[string]tftypes.Type{}
+	for n, at := range obj.AttributeTypes {
+		if !newAttrs[block+"."+n] {
+			attrs[n] = at
+		}
+	}
+	if t.Is(tftypes.Set{}) {
+		return tftypes.Set{ElementType: tftypes.Object{AttributeTypes: attrs}}
+	}
+	return tftypes.List{ElementType: tftypes.Object{AttributeTypes: attrs}}
+}
+
 func tfKind(t tftypes.Type) string {
 	switch {
 	case t.Is(tftypes.String):
@@ -0,0 +1,179 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// timeseriesBucketsPrefix names the internal collection that holds the data
+// of a time-series collection.
+const timeseriesBucketsPrefix = "system.buckets."
+
+var timeseriesObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"time_field":              types.StringType,
+	"meta_field":              types.StringType,
+	"granularity":             types.StringType,
+	"bucket_max_span_seconds": types.Int64Type,
+	"bucket_rounding_seconds": types.Int64Type,
+}}
+
+type timeseriesModel struct {
+	TimeField             types.String `tfsdk:"time_field"`
+	MetaField             types.String `tfsdk:"meta_field"`
+	Granularity           types.String `tfsdk:"granularity"`
+	BucketMaxSpanSeconds  types.Int64  `tfsdk:"bucket_max_span_seconds"`
+	BucketRoundingSeconds types.Int64  `tfsdk:"bucket_rounding_seconds"`
+}
+
+// timeseriesGranularities lists the granularities in the only order collMod
+// can change them: coarser, never finer.
+var timeseriesGranularities = []string{"seconds", "minutes", "hours"}
+
+func granularityRank(granularity string) int {
+	for i, g := range timeseriesGranularities {
+		if g == granularity {
+			return i
+		}
+	}
+	return -1
+}
+
+// timeseriesBlock describes a time-series collection. Adding or removing the
+// block, or changing a field other than granularity, forces a new collection;
+// granularity can be made coarser in place.
+func timeseriesBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Makes this a time-series collection.",
+		Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+				resp.RequiresReplace = len(req.StateValue.Elements()) != len(req.PlanValue.Elements())
+			}, "Adding or removing timeseries forces a new collection.", "Adding or removing `timeseries` forces a new collection."),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"time_field": schema.StringAttribute{
+					Required:      true,
+					Description:   "Field holding each measurement's date.",
+					PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				},
+				"meta_field": schema.StringAttribute{
+					Optional:      true,
+					Description:   "Field holding the metadata that identifies a series.",
+					PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				},
+				"granularity": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Expected interval between measurements of a series: \"seconds\" (server default), \"minutes\" or \"hours\". Can be made coarser in place.",
+					Validators:  []validator.String{stringvalidator.OneOf(timeseriesGranularities...)},
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+						stringplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+								return
+							}
+							resp.RequiresReplace = granularityRank(req.PlanValue.ValueString()) < granularityRank(req.StateValue.ValueString())
+						}, "Making granularity finer forces a new collection.", "Making `granularity` finer forces a new collection."),
+					},
+				},
+				"bucket_max_span_seconds": schema.Int64Attribute{
+					Optional:      true,
+					Description:   "Custom bucketing (MongoDB 6.3+): maximum time span of a bucket. Must equal bucket_rounding_seconds; not allowed with granularity.",
+					Validators:    []validator.Int64{int64validator.Between(1, 31536000)},
+					PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+				},
+				"bucket_rounding_seconds": schema.Int64Attribute{
+					Optional:      true,
+					Description:   "Custom bucketing (MongoDB 6.3+): interval bucket start times are rounded down to.",
+					Validators:    []validator.Int64{int64validator.Between(1, 31536000)},
+					PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+				},
+			},
+		},
+	}
+}
+
+// timeseriesFromList converts the configured block into driver options, or
+// nil when the collection is not a time-series collection.
+func timeseriesFromList(ctx context.Context, list types.List) (*timeseriesModel, *options.TimeSeriesOptionsBuilder, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return nil, nil, nil
+	}
+	var models []timeseriesModel
+	if diags := list.ElementsAs(ctx, &models, false); diags.HasError() {
+		return nil, nil, diags
+	}
+	m := models[0]
+	opts := options.TimeSeries().SetTimeField(m.TimeField.ValueString())
+	if !m.MetaField.IsNull() {
+		opts.SetMetaField(m.MetaField.ValueString())
+	}
+	if !m.Granularity.IsNull() && !m.Granularity.IsUnknown() {
+		opts.SetGranularity(m.Granularity.ValueString())
+	}
+	if !m.BucketMaxSpanSeconds.IsNull() {
+		opts.SetBucketMaxSpan(time.Duration(m.BucketMaxSpanSeconds.ValueInt64()) * time.Second)
+	}
+	if !m.BucketRoundingSeconds.IsNull() {
+		opts.SetBucketRounding(time.Duration(m.BucketRoundingSeconds.ValueInt64()) * time.Second)
+	}
+	return &m, opts, nil
+}
+
+// timeseriesList builds the state value from the timeseries options of a
+// collection specification. The server also reports bucketMaxSpanSeconds
+// for a granularity-based collection, so the bucket fields are only kept
+// when declared, or when there is no granularity to explain them.
+func timeseriesList(doc bson.Raw, prior types.List) types.List {
+	if doc == nil {
+		return types.ListValueMust(timeseriesObjectType, []attr.Value{})
+	}
+	declared := timeseriesModel{
+		BucketMaxSpanSeconds:  types.Int64Null(),
+		BucketRoundingSeconds: types.Int64Null(),
+	}
+	if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) > 0 {
+		attrs := prior.Elements()[0].(types.Object).Attributes()
+		declared.BucketMaxSpanSeconds, _ = attrs["bucket_max_span_seconds"].(types.Int64)
+		declared.BucketRoundingSeconds, _ = attrs["bucket_rounding_seconds"].(types.Int64)
+	}
+
+	timeField, _ := doc.Lookup("timeField").StringValueOK()
+	values := map[string]attr.Value{
+		"time_field":              types.StringValue(timeField),
+		"meta_field":              types.StringNull(),
+		"granularity":             types.StringNull(),
+		"bucket_max_span_seconds": types.Int64Null(),
+		"bucket_rounding_seconds": types.Int64Null(),
+	}
+	if metaField, ok := doc.Lookup("metaField").StringValueOK(); ok {
+		values["meta_field"] = types.StringValue(metaField)
+	}
+	granularity, hasGranularity := doc.Lookup("granularity").StringValueOK()
+	if hasGranularity {
+		values["granularity"] = types.StringValue(granularity)
+	}
+	if span, ok := doc.Lookup("bucketMaxSpanSeconds").AsInt64OK(); ok && (!hasGranularity || !declared.BucketMaxSpanSeconds.IsNull()) {
+		values["bucket_max_span_seconds"] = types.Int64Value(span)
+	}
+	if rounding, ok := doc.Lookup("bucketRoundingSeconds").AsInt64OK(); ok && (!hasGranularity || !declared.BucketRoundingSeconds.IsNull()) {
+		values["bucket_rounding_seconds"] = types.Int64Value(rounding)
+	}
+	return types.ListValueMust(timeseriesObjectType, []attr.Value{types.ObjectValueMust(timeseriesObjectType.AttrTypes, values)})
+}
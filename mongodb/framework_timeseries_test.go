@@ -0,0 +1,64 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestTimeseriesList(t *testing.T) {
+	withGranularity, _ := bson.Marshal(bson.D{
+		{Key: "timeField", Value: "ts"},
+		{Key: "metaField", Value: "sensor"},
+		{Key: "granularity", Value: "minutes"},
+		{Key: "bucketMaxSpanSeconds", Value: int32(86400)},
+	})
+	customBuckets, _ := bson.Marshal(bson.D{
+		{Key: "timeField", Value: "ts"},
+		{Key: "bucketRoundingSeconds", Value: int32(300)},
+		{Key: "bucketMaxSpanSeconds", Value: int32(300)},
+	})
+	expect := func(meta, granularity types.String, span, rounding types.Int64) types.List {
+		return types.ListValueMust(timeseriesObjectType, []attr.Value{
+			types.ObjectValueMust(timeseriesObjectType.AttrTypes, map[string]attr.Value{
+				"time_field":              types.StringValue("ts"),
+				"meta_field":              meta,
+				"granularity":             granularity,
+				"bucket_max_span_seconds": span,
+				"bucket_rounding_seconds": rounding,
+			}),
+		})
+	}
+
+	cases := []struct {
+		name  string
+		doc   bson.Raw
+		prior types.List
+		want  types.List
+	}{
+		{
+			name:  "not a time-series collection",
+			prior: types.ListNull(timeseriesObjectType),
+			want:  types.ListValueMust(timeseriesObjectType, []attr.Value{}),
+		},
+		{
+			name:  "granularity hides the derived bucket span",
+			doc:   withGranularity,
+			prior: types.ListNull(timeseriesObjectType),
+			want:  expect(types.StringValue("sensor"), types.StringValue("minutes"), types.Int64Null(), types.Int64Null()),
+		},
+		{
+			name:  "custom bucketing is read back",
+			doc:   customBuckets,
+			prior: types.ListNull(timeseriesObjectType),
+			want:  expect(types.StringNull(), types.StringNull(), types.Int64Value(300), types.Int64Value(300)),
+		},
+	}
+	for _, tc := range cases {
+		if got := timeseriesList(tc.doc, tc.prior); !got.Equal(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
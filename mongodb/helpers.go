@@ -2,11 +2,13 @@ package mongodb
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"regexp"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 	"strings"
 )
 
@@ -30,6 +32,32 @@ func validateDiagFunc(validateFunc func(interface{}, string) ([]string, []error)
 	}
 }
 
+// notFoundError is returned by the read helpers when the MongoDB object backing
+// a resource no longer exists, e.g. because it was dropped by hand.
+type notFoundError struct {
+	kind string
+}
+
+func (e notFoundError) Error() string {
+	return e.kind + " does not exist"
+}
+
+// namespaceNotFoundCode is the server error code for a missing database or
+// collection (NamespaceNotFound).
+const namespaceNotFoundCode = 26
+
+// isNotFound reports whether err means the object was removed outside
+// Terraform, so Read can drop it from state. Connection, permission and other
+// server errors are not matched and must still be surfaced.
+func isNotFound(err error) bool {
+	var nf notFoundError
+	if errors.As(err, &nf) {
+		return true
+	}
+	var ce mongo.CommandError
+	return errors.As(err, &ce) && ce.Code == namespaceNotFoundCode
+}
+
 func ParseId(id string, expectedParts int) ([]string, error) {
 	result, errEncoding := base64.StdEncoding.DecodeString(id)
 	if errEncoding != nil {
@@ -1,11 +1,13 @@
 package mongodb
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 	"pgregory.net/rapid"
 )
 
@@ -65,6 +67,27 @@ func TestValidateIAMARN_Invalid(t *testing.T) {
 	}
 }
 
+// TestIsNotFound — only missing-object errors let Read drop a resource from
+// state; connection and permission failures must not match.
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"read helper not-found", notFoundError{kind: "user"}, true},
+		{"wrapped not-found", fmt.Errorf("reading: %w", notFoundError{kind: "index"}), true},
+		{"namespace not found", mongo.CommandError{Code: 26, Name: "NamespaceNotFound"}, true},
+		{"unauthorized", mongo.CommandError{Code: 13, Name: "Unauthorized"}, false},
+		{"connection error", errors.New("server selection error: context deadline exceeded"), false},
+	}
+	for _, tc := range cases {
+		if got := isNotFound(tc.err); got != tc.want {
+			t.Errorf("%s: isNotFound = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Property-based tests
 // ---------------------------------------------------------------------------
@@ -3,11 +3,13 @@ package mongodb
 import (
 	"context"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 func Provider() *schema.Provider {
@@ -107,6 +109,26 @@ func Provider() *schema.Provider {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "Additional properties for the selected auth_mechanism, e.g. ENVIRONMENT and TOKEN_RESOURCE for MONGODB-OIDC or AWS_SESSION_TOKEN for MONGODB-AWS.",
 			},
+			"connect_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				Description:      "Seconds to wait for a new connection to the mongodb server to be established",
+				ValidateDiagFunc: validateDiagFunc(validation.IntAtLeast(0)),
+			},
+			"server_selection_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				Description:      "Seconds to wait for a suitable mongodb server to become available for an operation",
+				ValidateDiagFunc: validateDiagFunc(validation.IntAtLeast(0)),
+			},
+			"max_pool_size": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "Maximum number of connections in the pool shared by all resources",
+				ValidateDiagFunc: validateDiagFunc(validation.IntAtLeast(1)),
+			},
 		},
 		// All resources are now served by the terraform-plugin-framework half
 		// (see framework_*.go), muxed alongside this SDKv2 provider. They must
@@ -118,9 +140,13 @@ func Provider() *schema.Provider {
 	}
 }
 
+// MongoDatabaseConfiguration is the provider data handed to every resource. It
+// owns the single pooled client those resources share (see MongoClientInit).
 type MongoDatabaseConfiguration struct {
-	Config          *ClientConfig
-	MaxConnLifetime time.Duration
+	Config *ClientConfig
+
+	mu     sync.Mutex
+	client *mongo.Client
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -143,6 +169,10 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		AuthMechanism:      d.Get("auth_mechanism").(string),
 	}
 
+	clientConfig.ConnectTimeout = time.Duration(d.Get("connect_timeout").(int)) * time.Second
+	clientConfig.ServerSelectionTimeout = time.Duration(d.Get("server_selection_timeout").(int)) * time.Second
+	clientConfig.MaxPoolSize = uint64(d.Get("max_pool_size").(int))
+
 	if props, ok := d.GetOk("auth_mechanism_properties"); ok {
 		raw := props.(map[string]interface{})
 		clientConfig.AuthMechanismProperties = make(map[string]string, len(raw))
@@ -151,8 +181,5 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		}
 	}
 
-	return &MongoDatabaseConfiguration{
-		Config:          &clientConfig,
-		MaxConnLifetime: 10,
-	}, diags
+	return &MongoDatabaseConfiguration{Config: &clientConfig}, diags
 }
@@ -35,7 +35,6 @@ func testAccMongoConfig() *MongoDatabaseConfiguration {
 			Password: getEnvWithDefault("MONGO_PWD", "root"),
 			DB:       getEnvWithDefault("MONGO_AUTH_DB", "admin"),
 		},
-		MaxConnLifetime: 10,
 	}
 }
 
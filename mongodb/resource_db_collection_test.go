@@ -2,12 +2,16 @@ package mongodb
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
@@ -100,6 +104,507 @@ func TestAccMongoDBCollection_Update(t *testing.T) {
 	})
 }
 
+// TestAccMongoDBCollection_disappears drops the collection outside Terraform and
+// verifies the refresh removes it from state (planning a re-create) instead of
+// failing the plan.
+func TestAccMongoDBCollection_disappears(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionBasic(databaseName, collectionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					testAccCheckMongoDBCollectionDisappears(databaseName, collectionName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccMongoDBCollection_Collation creates a collection with a default
+// collation and checks that an index without its own collation inherits it
+// without showing a diff.
+func TestAccMongoDBCollection_Collation(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionCollation(databaseName, collectionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "collation.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "collation.0.locale", "fr"),
+					resource.TestCheckResourceAttr(resourceName, "collation.0.numeric_ordering", "true"),
+					resource.TestCheckResourceAttr("mongodb_db_index.test", "collation.#", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBCollectionCollation(dbName, collectionName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+  collation {
+    locale           = "fr"
+    numeric_ordering = true
+  }
+}
+
+resource "mongodb_db_index" "test" {
+  db         = mongodb_db_collection.test.db
+  collection = mongodb_db_collection.test.name
+  keys {
+    field = "product_name"
+    value = "1"
+  }
+}
+`, dbName, collectionName)
+}
+
+// TestAccMongoDBCollection_Validator creates a collection with a $jsonSchema
+// validator, then relaxes and removes it in place with collMod.
+func TestAccMongoDBCollection_Validator(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionValidator(databaseName, collectionName, `jsonencode({ "$jsonSchema" = { required = ["email"] } })`, "moderate", "error"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "validation_level", "moderate"),
+					resource.TestCheckResourceAttr(resourceName, "validation_action", "error"),
+					testAccCheckMongoDBCollectionRejects(databaseName, collectionName, bson.D{{Key: "name", Value: "no email"}}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			{
+				Config: testAccMongoDBCollectionValidator(databaseName, collectionName, `jsonencode({ "$jsonSchema" = { required = ["email"] } })`, "strict", "warn"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "validation_level", "strict"),
+					resource.TestCheckResourceAttr(resourceName, "validation_action", "warn"),
+				),
+			},
+			{
+				// A validator written by hand is kept as written, not
+				// rewritten to the server's compact spelling.
+				Config: testAccMongoDBCollectionValidator(databaseName, collectionName, `<<-EOT
+    {
+      "$jsonSchema": {
+        "required": ["email"],
+        "properties": { "age": { "minimum": { "$numberLong": "0" } } }
+      }
+    }
+  EOT`, "strict", "warn"),
+				Check: resource.TestCheckResourceAttrWith(resourceName, "validator", func(value string) error {
+					if !strings.Contains(value, "\n") {
+						return fmt.Errorf("validator was rewritten to %s", value)
+					}
+					return nil
+				}),
+			},
+			{
+				Config: testAccMongoDBCollectionValidator(databaseName, collectionName, `""`, "strict", "error"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "validator", ""),
+					testAccMongoDBInsertDocuments(databaseName, collectionName, bson.D{{Key: "name", Value: "no email"}}),
+				),
+			},
+		},
+	})
+}
+
+func testAccMongoDBCollectionValidator(dbName, collectionName, validator, level, action string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+  validator           = %[3]s
+  validation_level    = %[4]q
+  validation_action   = %[5]q
+}
+`, dbName, collectionName, validator, level, action)
+}
+
+func testAccCheckMongoDBCollectionRejects(dbName, collectionName string, doc bson.D) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		if _, err := client.Database(dbName).Collection(collectionName).InsertOne(context.Background(), doc); err == nil {
+			return fmt.Errorf("insert of %v was accepted, want a validation error", doc)
+		}
+		return nil
+	}
+}
+
+// TestAccMongoDBCollection_Capped creates a capped collection and resizes it,
+// which happens in place on MongoDB 6.0 and later.
+func TestAccMongoDBCollection_Capped(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMongoDBCollectionCappedMissingSize(databaseName, collectionName),
+				ExpectError: regexp.MustCompile(`A capped collection requires size`),
+			},
+			{
+				Config: testAccMongoDBCollectionCapped(databaseName, collectionName, 1048576, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "capped", "true"),
+					resource.TestCheckResourceAttr(resourceName, "size", "1048576"),
+					resource.TestCheckResourceAttr(resourceName, "max", "1000"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			{
+				Config: testAccMongoDBCollectionCapped(databaseName, collectionName, 2097152, 5000),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "size", "2097152"),
+					resource.TestCheckResourceAttr(resourceName, "max", "5000"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMongoDBCollectionCapped(dbName, collectionName string, size, max int) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+  capped              = true
+  size                = %[3]d
+  max                 = %[4]d
+}
+`, dbName, collectionName, size, max)
+}
+
+func testAccMongoDBCollectionCappedMissingSize(dbName, collectionName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+  capped              = true
+}
+`, dbName, collectionName)
+}
+
+// TestAccMongoDBCollection_Timeseries creates a time-series collection, then
+// coarsens its granularity and changes its expiry in place.
+func TestAccMongoDBCollection_Timeseries(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionTimeseries(databaseName, collectionName, "seconds", 86400),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "timeseries.0.time_field", "ts"),
+					resource.TestCheckResourceAttr(resourceName, "timeseries.0.meta_field", "sensor"),
+					resource.TestCheckResourceAttr(resourceName, "timeseries.0.granularity", "seconds"),
+					resource.TestCheckNoResourceAttr(resourceName, "timeseries.0.bucket_max_span_seconds"),
+					resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "86400"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			{
+				Config: testAccMongoDBCollectionTimeseries(databaseName, collectionName, "minutes", 3600),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "timeseries.0.granularity", "minutes"),
+					resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "3600"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMongoDBCollectionTimeseries(dbName, collectionName, granularity string, expireAfterSeconds int) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                   = %[1]q
+  name                 = %[2]q
+  deletion_protection  = false
+  expire_after_seconds = %[4]d
+
+  timeseries {
+    time_field  = "ts"
+    meta_field  = "sensor"
+    granularity = %[3]q
+  }
+}
+`, dbName, collectionName, granularity, expireAfterSeconds)
+}
+
+// TestAccMongoDBCollection_Clustered creates a clustered collection with a
+// TTL and changes the TTL in place.
+func TestAccMongoDBCollection_Clustered(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionClustered(databaseName, collectionName, 86400),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "clustered_index.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "clustered_index.0.name", "_id_"),
+					resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "86400"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			{
+				Config: testAccMongoDBCollectionClustered(databaseName, collectionName, 3600),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "3600"),
+			},
+		},
+	})
+}
+
+func testAccMongoDBCollectionClustered(dbName, collectionName string, expireAfterSeconds int) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                   = %[1]q
+  name                 = %[2]q
+  deletion_protection  = false
+  expire_after_seconds = %[3]d
+
+  clustered_index {}
+}
+`, dbName, collectionName, expireAfterSeconds)
+}
+
+// TestAccMongoDBCollection_Rename renames a collection in place with
+// previous_name, then moves it to another database with previous_db, and
+// checks that its documents come along.
+func TestAccMongoDBCollection_Rename(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	var otherDatabaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_collection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBCollectionBasic(databaseName, collectionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					testAccMongoDBInsertDocuments(databaseName, collectionName, bson.D{{Key: "n", Value: 1}}),
+				),
+			},
+			{
+				Config: testAccMongoDBCollectionRenamed(databaseName, collectionName+"-renamed", "", collectionName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", base64.StdEncoding.EncodeToString([]byte(databaseName+"."+collectionName+"-renamed"))),
+					testAccCheckMongoDBCollectionCount(databaseName, collectionName+"-renamed", 1),
+				),
+			},
+			{
+				Config: testAccMongoDBCollectionRenamed(otherDatabaseName, collectionName+"-renamed", databaseName, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBCollectionExists(resourceName),
+					testAccCheckMongoDBCollectionCount(otherDatabaseName, collectionName+"-renamed", 1),
+				),
+			},
+			{
+				// Without a matching previous_name a new name still replaces.
+				Config: testAccMongoDBCollectionRenamed(otherDatabaseName, collectionName+"-new", "", collectionName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccMongoDBCollectionRenamed(dbName, collectionName, previousDb, previousName string) string {
+	var previous string
+	if previousDb != "" {
+		previous += fmt.Sprintf("  previous_db         = %q\n", previousDb)
+	}
+	if previousName != "" {
+		previous += fmt.Sprintf("  previous_name       = %q\n", previousName)
+	}
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+%[3]s}
+`, dbName, collectionName, previous)
+}
+
+func testAccCheckMongoDBCollectionCount(dbName, collectionName string, want int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		count, err := client.Database(dbName).Collection(collectionName).CountDocuments(context.Background(), bson.D{})
+		if err != nil {
+			return fmt.Errorf("error counting documents: %s", err)
+		}
+		if count != want {
+			return fmt.Errorf("%s.%s has %d documents, want %d", dbName, collectionName, count, want)
+		}
+		return nil
+	}
+}
+
+func TestCollectionRenamed(t *testing.T) {
+	state := dbCollectionResourceModel{Db: types.StringValue("app"), Name: types.StringValue("users")}
+	plan := func(db, name, previousDb, previousName string) dbCollectionResourceModel {
+		m := dbCollectionResourceModel{
+			Db:           types.StringValue(db),
+			Name:         types.StringValue(name),
+			PreviousDb:   types.StringNull(),
+			PreviousName: types.StringNull(),
+		}
+		if previousDb != "" {
+			m.PreviousDb = types.StringValue(previousDb)
+		}
+		if previousName != "" {
+			m.PreviousName = types.StringValue(previousName)
+		}
+		return m
+	}
+	cases := []struct {
+		name string
+		plan dbCollectionResourceModel
+		want bool
+	}{
+		{"unchanged", plan("app", "users", "", "users"), false},
+		{"new name without hint", plan("app", "members", "", ""), false},
+		{"rename", plan("app", "members", "", "users"), true},
+		{"hint names another collection", plan("app", "members", "", "accounts"), false},
+		{"move to another db", plan("archive", "users", "app", ""), true},
+		{"move and rename", plan("archive", "members", "app", "users"), true},
+		{"move without previous_db", plan("archive", "members", "", "users"), false},
+	}
+	for _, tc := range cases {
+		if got := collectionRenamed(tc.plan, state); got != tc.want {
+			t.Errorf("%s: collectionRenamed = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func testAccCheckMongoDBCollectionDisappears(dbName, collectionName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		return client.Database(dbName).Collection(collectionName).Drop(context.Background())
+	}
+}
+
 func testAccCheckMongoDBCollectionExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -235,3 +740,77 @@ resource "mongodb_db_collection" "test" {
 }
 `, dbName, collectionName)
 }
+
+func TestSameExtJSONDocument(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"whitespace", `{"$jsonSchema": {"required": ["email"]}}`, `{"$jsonSchema":{"required":["email"]}}`, true},
+		{"number spelling", `{"age": {"$gte": 18}}`, `{"age": {"$gte": {"$numberInt": "18"}}}`, true},
+		{"different value", `{"age": {"$gte": 18}}`, `{"age": {"$gte": 21}}`, false},
+		{"field order", `{"a": 1, "b": 1}`, `{"b": 1, "a": 1}`, false},
+		{"both empty", "", "", true},
+		{"one empty", "", `{}`, false},
+		{"invalid JSON", `{"a":`, `{"a": 1}`, false},
+	}
+	for _, tc := range cases {
+		if got := sameExtJSONDocument(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s: sameExtJSONDocument(%s, %s) = %v, want %v", tc.name, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestStorageEngineString(t *testing.T) {
+	zstd, _ := bson.Marshal(bson.D{{Key: "wiredTiger", Value: bson.D{{Key: "configString", Value: "block_compressor=zstd"}}}})
+	cases := []struct {
+		name  string
+		raw   bson.Raw
+		prior types.String
+		want  string
+	}{
+		{"no options", nil, types.StringNull(), ""},
+		{"empty document", bson.Raw{5, 0, 0, 0, 0}, types.StringValue(""), ""},
+		{"imported", zstd, types.StringNull(), `{"wiredTiger":{"configString":"block_compressor=zstd"}}`},
+		{"configString form kept", zstd, types.StringValue("block_compressor=zstd"), "block_compressor=zstd"},
+		{"document spelling kept", zstd, types.StringValue(`{ "wiredTiger": { "configString": "block_compressor=zstd" } }`), `{ "wiredTiger": { "configString": "block_compressor=zstd" } }`},
+		{"changed outside Terraform", zstd, types.StringValue("block_compressor=snappy"), `{"wiredTiger":{"configString":"block_compressor=zstd"}}`},
+	}
+	for _, tc := range cases {
+		got, err := storageEngineString(tc.raw, tc.prior)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+			continue
+		}
+		if got.ValueString() != tc.want {
+			t.Errorf("%s: storageEngineString = %q, want %q", tc.name, got.ValueString(), tc.want)
+		}
+	}
+}
+
+func TestValidatorString(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{{Key: "$jsonSchema", Value: bson.D{{Key: "minProperties", Value: int64(1)}}}})
+	heredoc := "{\n  \"$jsonSchema\": {\n    \"minProperties\": {\"$numberLong\": \"1\"}\n  }\n}\n"
+	cases := []struct {
+		name  string
+		raw   bson.Raw
+		prior types.String
+		want  string
+	}{
+		{"no validator", nil, types.StringValue(`{"a": 1}`), ""},
+		{"imported", raw, types.StringNull(), `{"$jsonSchema":{"minProperties":1}}`},
+		{"configured spelling kept", raw, types.StringValue(heredoc), heredoc},
+		{"changed outside Terraform", raw, types.StringValue(`{"$jsonSchema": {"minProperties": 2}}`), `{"$jsonSchema":{"minProperties":1}}`},
+	}
+	for _, tc := range cases {
+		got, err := validatorString(tc.raw, tc.prior)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+			continue
+		}
+		if got.ValueString() != tc.want {
+			t.Errorf("%s: validatorString = %q, want %q", tc.name, got.ValueString(), tc.want)
+		}
+	}
+}
@@ -3,12 +3,19 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 func TestAccMongoDBIndex_Basic(t *testing.T) {
@@ -122,17 +129,16 @@ func TestAccMongoDBIndex_TTLIndex(t *testing.T) {
 		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccMongoDBIndexTTL(databaseName, collectionName, indexName),
+				Config: testAccMongoDBIndexTTL(databaseName, collectionName, indexName, 3600),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckMongoDBIndexExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "db", databaseName),
 					resource.TestCheckResourceAttr(resourceName, "collection", collectionName),
 					resource.TestCheckResourceAttr(resourceName, "name", indexName),
-					resource.TestCheckResourceAttr(resourceName, "keys.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "keys.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "keys.0.field", "created_at"),
 					resource.TestCheckResourceAttr(resourceName, "keys.0.value", "1"),
-					resource.TestCheckResourceAttr(resourceName, "keys.1.field", "expireAfterSeconds"),
-					resource.TestCheckResourceAttr(resourceName, "keys.1.value", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "3600"),
 				),
 			},
 			{
@@ -145,6 +151,234 @@ func TestAccMongoDBIndex_TTLIndex(t *testing.T) {
 	})
 }
 
+// TestAccMongoDBIndex_TTLUpdate changes the expiry of a TTL index and expects
+// an in-place collMod rather than a drop and rebuild.
+func TestAccMongoDBIndex_TTLUpdate(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-test")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-test")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexTTL(databaseName, collectionName, indexName, 3600),
+				Check:  resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "3600"),
+			},
+			{
+				Config: testAccMongoDBIndexTTL(databaseName, collectionName, indexName, 86400),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "expire_after_seconds", "86400"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccMongoDBIndex_ConvertToUnique flips unique on an existing index. A
+// duplicate key first blocks the conversion and is reported; once it is
+// removed the index is converted in place (MongoDB 6.0+).
+func TestAccMongoDBIndex_ConvertToUnique(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-idx")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexUniqueToggle(databaseName, collectionName, indexName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "unique", "false"),
+					testAccMongoDBInsertDocuments(databaseName, collectionName,
+						bson.D{{Key: "_id", Value: 1}, {Key: "email", Value: "a@example.com"}},
+						bson.D{{Key: "_id", Value: 2}, {Key: "email", Value: "a@example.com"}},
+						bson.D{{Key: "_id", Value: 3}, {Key: "email", Value: "b@example.com"}},
+					),
+				),
+			},
+			{
+				Config:      testAccMongoDBIndexUniqueToggle(databaseName, collectionName, indexName, true),
+				ExpectError: regexp.MustCompile(`\{"email":"a@example.com"\} \(2 documents\)`),
+			},
+			{
+				PreConfig: func() {
+					client, err := MongoClientInit(testAccMongoConfig())
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := client.Database(databaseName).Collection(collectionName).DeleteOne(context.Background(), bson.M{"_id": 2}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccMongoDBIndexUniqueToggle(databaseName, collectionName, indexName, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "unique", "true"),
+			},
+		},
+	})
+}
+
+func testAccMongoDBInsertDocuments(dbName, collectionName string, docs ...bson.D) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		batch := make([]interface{}, 0, len(docs))
+		for _, doc := range docs {
+			batch = append(batch, doc)
+		}
+		_, err = client.Database(dbName).Collection(collectionName).InsertMany(context.Background(), batch)
+		return err
+	}
+}
+
+func testAccMongoDBIndexUniqueToggle(dbName, collectionName, indexName string, unique bool) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = %[3]q
+  keys {
+    field = "email"
+    value = "1"
+  }
+  unique = %[4]t
+}
+`, dbName, collectionName, indexName, unique)
+}
+
+// TestAccMongoDBIndex_AdoptExisting takes over an index an application already
+// created. A conflicting configuration fails with the differences; a matching
+// one adopts the index without rebuilding it.
+func TestAccMongoDBIndex_AdoptExisting(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			client, err := MongoClientInit(testAccMongoConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = client.Database(databaseName).Collection(collectionName).Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys:    bson.D{{Key: "email", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckMongoDBIndexDestroy(s); err != nil {
+				return err
+			}
+			client, err := MongoClientInit(testAccMongoConfig())
+			if err != nil {
+				return err
+			}
+			return client.Database(databaseName).Drop(context.Background())
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMongoDBIndexAdoptExisting(databaseName, collectionName, false),
+				ExpectError: regexp.MustCompile(`unique: configured false, existing true`),
+			},
+			{
+				Config: testAccMongoDBIndexAdoptExisting(databaseName, collectionName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "email_1"),
+					resource.TestCheckResourceAttr(resourceName, "unique", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexAdoptExisting(dbName, collectionName string, unique bool) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_index" "test" {
+  db         = %[1]q
+  collection = %[2]q
+  keys {
+    field = "email"
+    value = "1"
+  }
+  unique         = %[3]t
+  adopt_existing = true
+}
+`, dbName, collectionName, unique)
+}
+
+func TestIndexDifferences(t *testing.T) {
+	keys := types.ListValueMust(dbIndexKeyObjectType, []attr.Value{mustIndexKeyObject("email", "1")})
+	plan := dbIndexResourceModel{
+		Keys:                    keys,
+		Unique:                  types.BoolValue(true),
+		Sparse:                  types.BoolValue(false),
+		ExpireAfterSeconds:      types.Int64Null(),
+		PartialFilterExpression: types.StringValue(""),
+		Hidden:                  types.BoolValue(false),
+		Collation:               types.ListValueMust(collationObjectType, []attr.Value{}),
+		Weights:                 types.MapNull(types.Int64Type),
+		DefaultLanguage:         types.StringUnknown(),
+		LanguageOverride:        types.StringUnknown(),
+		TextIndexVersion:        types.Int64Unknown(),
+		Bits:                    types.Int64Null(),
+		Min:                     types.Float64Null(),
+		Max:                     types.Float64Null(),
+		SphereIndexVersion:      types.Int64Unknown(),
+		WildcardProjection:      types.StringValue(""),
+	}
+	existing := plan
+	existing.DefaultLanguage = types.StringNull()
+	existing.LanguageOverride = types.StringNull()
+	existing.TextIndexVersion = types.Int64Null()
+	existing.SphereIndexVersion = types.Int64Null()
+
+	if got := indexDifferences(plan, existing); len(got) != 0 {
+		t.Errorf("identical index: got differences %v", got)
+	}
+
+	existing.Unique = types.BoolValue(false)
+	existing.ExpireAfterSeconds = types.Int64Value(60)
+	want := []string{
+		"unique: configured true, existing false",
+		"expire_after_seconds: configured <null>, existing 60",
+	}
+	if got := indexDifferences(plan, existing); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
 func TestAccMongoDBIndex_GeneratedName(t *testing.T) {
 	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
 	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
@@ -188,15 +422,15 @@ func TestAccMongoDBIndex_Unique(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "db", databaseName),
 					resource.TestCheckResourceAttr(resourceName, "collection", collectionName),
 					resource.TestCheckResourceAttr(resourceName, "name", indexName),
-					resource.TestCheckResourceAttr(resourceName, "keys.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "keys.#", "3"),
 					resource.TestCheckResourceAttr(resourceName, "keys.0.field", "entity_type"),
 					resource.TestCheckResourceAttr(resourceName, "keys.0.value", "1"),
 					resource.TestCheckResourceAttr(resourceName, "keys.1.field", "entity_id"),
 					resource.TestCheckResourceAttr(resourceName, "keys.1.value", "1"),
 					resource.TestCheckResourceAttr(resourceName, "keys.2.field", "profile_type"),
 					resource.TestCheckResourceAttr(resourceName, "keys.2.value", "1"),
-					resource.TestCheckResourceAttr(resourceName, "keys.3.field", "unique"),
-					resource.TestCheckResourceAttr(resourceName, "keys.3.value", "true"),
+					resource.TestCheckResourceAttr(resourceName, "unique", "true"),
+					resource.TestCheckResourceAttr(resourceName, "sparse", "false"),
 				),
 			},
 			{
@@ -225,13 +459,10 @@ func TestAccMongoDBIndex_Sparse(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckMongoDBIndexExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", indexName),
-					// read-back order: real keys, then unique, then sparse
-					resource.TestCheckResourceAttr(resourceName, "keys.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "keys.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "keys.0.field", "email"),
-					resource.TestCheckResourceAttr(resourceName, "keys.1.field", "unique"),
-					resource.TestCheckResourceAttr(resourceName, "keys.1.value", "true"),
-					resource.TestCheckResourceAttr(resourceName, "keys.2.field", "sparse"),
-					resource.TestCheckResourceAttr(resourceName, "keys.2.value", "true"),
+					resource.TestCheckResourceAttr(resourceName, "unique", "true"),
+					resource.TestCheckResourceAttr(resourceName, "sparse", "true"),
 				),
 			},
 			{
@@ -671,7 +902,7 @@ resource "mongodb_db_index" "test" {
 `, dbName, collectionName, dbName, collectionName, indexName)
 }
 
-func testAccMongoDBIndexTTL(dbName, collectionName, indexName string) string {
+func testAccMongoDBIndexTTL(dbName, collectionName, indexName string, expireAfterSeconds int) string {
 	return fmt.Sprintf(`
 resource "mongodb_db_collection" "test" {
   db                   = "%s"
@@ -688,13 +919,10 @@ resource "mongodb_db_index" "test" {
     field = "created_at"
     value = "1"
   }
-  keys {
-    field = "expireAfterSeconds"
-    value = "3600"
-  }
-  timeout = 30
+  expire_after_seconds = %d
+  timeout              = 30
 }
-`, dbName, collectionName, dbName, collectionName, indexName)
+`, dbName, collectionName, dbName, collectionName, indexName, expireAfterSeconds)
 }
 
 func testAccMongoDBIndexGeneratedName(dbName, collectionName string) string {
@@ -743,10 +971,7 @@ resource "mongodb_db_index" "test" {
     field = "profile_type"
     value = "1"
   }
-  keys {
-    field = "unique"
-    value = "true"
-  }
+  unique  = true
   timeout = 30
 }
 `, dbName, collectionName, dbName, collectionName, indexName)
@@ -769,14 +994,8 @@ resource "mongodb_db_index" "test" {
     field = "email"
     value = "1"
   }
-  keys {
-    field = "unique"
-    value = "true"
-  }
-  keys {
-    field = "sparse"
-    value = "true"
-  }
+  unique  = true
+  sparse  = true
   timeout = 30
 }
 `, dbName, collectionName, dbName, collectionName, indexName)
@@ -896,3 +1115,607 @@ resource "mongodb_db_index" "test" {
 }
 `, dbName, collectionName, dbName, collectionName, indexName)
 }
+
+// TestAccMongoDBIndex_FieldNamedUnique indexes a real field called "unique",
+// which schema version 0 silently turned into the unique option.
+func TestAccMongoDBIndex_FieldNamedUnique(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-idx")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The pre-attribute spelling of unique = true is rejected
+				// rather than indexed as a field.
+				Config:      testAccMongoDBIndexFieldNamedUnique(databaseName, collectionName, indexName, "true"),
+				ExpectError: regexp.MustCompile(`set unique = true instead`),
+			},
+			{
+				Config: testAccMongoDBIndexFieldNamedUnique(databaseName, collectionName, indexName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "keys.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "keys.0.field", "unique"),
+					resource.TestCheckResourceAttr(resourceName, "keys.0.value", "1"),
+					resource.TestCheckResourceAttr(resourceName, "unique", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexFieldNamedUnique(dbName, collectionName, indexName, value string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = %[3]q
+  keys {
+    field = "unique"
+    value = %[4]q
+  }
+}
+`, dbName, collectionName, indexName, value)
+}
+
+func TestSplitLegacyIndexKeys(t *testing.T) {
+	key := func(field, value string) dbIndexKeyModel {
+		return dbIndexKeyModel{Field: types.StringValue(field), Value: types.StringValue(value)}
+	}
+	ttl := int64(3600)
+	cases := []struct {
+		name     string
+		keys     []dbIndexKeyModel
+		wantKeys []dbIndexKeyModel
+		want     legacyIndexOptions
+	}{
+		{
+			name:     "plain keys are kept",
+			keys:     []dbIndexKeyModel{key("a", "1"), key("b", "-1")},
+			wantKeys: []dbIndexKeyModel{key("a", "1"), key("b", "-1")},
+		},
+		{
+			name:     "options are lifted out in any case",
+			keys:     []dbIndexKeyModel{key("email", "1"), key("Unique", "TRUE"), key("sparse", "true"), key("expireAfterSeconds", "3600")},
+			wantKeys: []dbIndexKeyModel{key("email", "1")},
+			want:     legacyIndexOptions{unique: true, sparse: true, expireAfterSeconds: &ttl},
+		},
+		{
+			name:     "unique false is still an option",
+			keys:     []dbIndexKeyModel{key("a", "1"), key("unique", "false")},
+			wantKeys: []dbIndexKeyModel{key("a", "1")},
+		},
+		{
+			name:     "non-option values stay keys",
+			keys:     []dbIndexKeyModel{key("unique", "1"), key("expireAfterSeconds", "-1")},
+			wantKeys: []dbIndexKeyModel{key("unique", "1"), key("expireAfterSeconds", "-1")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotKeys, got := splitLegacyIndexKeys(tc.keys)
+			if !reflect.DeepEqual(gotKeys, tc.wantKeys) {
+				t.Errorf("keys: got %v, want %v", gotKeys, tc.wantKeys)
+			}
+			if got.unique != tc.want.unique || got.sparse != tc.want.sparse {
+				t.Errorf("options: got unique=%t sparse=%t, want unique=%t sparse=%t", got.unique, got.sparse, tc.want.unique, tc.want.sparse)
+			}
+			if !reflect.DeepEqual(got.expireAfterSeconds, tc.want.expireAfterSeconds) {
+				t.Errorf("expireAfterSeconds: got %v, want %v", got.expireAfterSeconds, tc.want.expireAfterSeconds)
+			}
+		})
+	}
+}
+
+// TestAccMongoDBIndex_Collation creates a case-insensitive unique index, the
+// typical use of an index collation.
+func TestAccMongoDBIndex_Collation(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-idx")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexCollation(databaseName, collectionName, indexName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "unique", "true"),
+					resource.TestCheckResourceAttr(resourceName, "collation.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "collation.0.locale", "en"),
+					resource.TestCheckResourceAttr(resourceName, "collation.0.strength", "2"),
+					resource.TestCheckResourceAttr(resourceName, "collation.0.case_first", "off"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexCollation(dbName, collectionName, indexName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = %[3]q
+  keys {
+    field = "email"
+    value = "1"
+  }
+  unique = true
+  collation {
+    locale   = "en"
+    strength = 2
+  }
+}
+`, dbName, collectionName, indexName)
+}
+
+// TestAccMongoDBIndex_Text creates a weighted compound text index and checks
+// that it reads back as the declared fields rather than _fts/_ftsx.
+func TestAccMongoDBIndex_Text(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-idx")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexText(databaseName, collectionName, indexName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "keys.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "keys.0.field", "category"),
+					resource.TestCheckResourceAttr(resourceName, "keys.1.field", "title"),
+					resource.TestCheckResourceAttr(resourceName, "keys.1.value", "text"),
+					resource.TestCheckResourceAttr(resourceName, "keys.2.field", "body"),
+					resource.TestCheckResourceAttr(resourceName, "keys.2.value", "text"),
+					resource.TestCheckResourceAttr(resourceName, "weights.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "weights.title", "10"),
+					resource.TestCheckResourceAttr(resourceName, "default_language", "spanish"),
+					resource.TestCheckResourceAttr(resourceName, "language_override", "idioma"),
+					resource.TestCheckResourceAttrSet(resourceName, "text_index_version"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexText(dbName, collectionName, indexName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = %[3]q
+  keys {
+    field = "category"
+    value = "1"
+  }
+  keys {
+    field = "title"
+    value = "text"
+  }
+  keys {
+    field = "body"
+    value = "text"
+  }
+  weights = {
+    title = 10
+  }
+  default_language  = "spanish"
+  language_override = "idioma"
+}
+`, dbName, collectionName, indexName)
+}
+
+func TestIndexKeysFromSpec(t *testing.T) {
+	key := func(field, value string) dbIndexKeyModel {
+		return dbIndexKeyModel{Field: types.StringValue(field), Value: types.StringValue(value)}
+	}
+	textSpec := bson.D{{Key: "category", Value: int32(1)}, {Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}}
+	weights := bson.D{{Key: "body", Value: int32(1)}, {Key: "title", Value: int32(10)}}
+	cases := []struct {
+		name    string
+		key     bson.D
+		weights bson.D
+		prior   []dbIndexKeyModel
+		want    []dbIndexKeyModel
+	}{
+		{
+			name: "plain keys",
+			key:  bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(-1)}, {Key: "loc", Value: "2dsphere"}},
+			want: []dbIndexKeyModel{key("a", "1"), key("b", "-1"), key("loc", "2dsphere")},
+		},
+		{
+			name:    "text fields keep declared order",
+			key:     textSpec,
+			weights: weights,
+			prior:   []dbIndexKeyModel{key("category", "1"), key("title", "text"), key("body", "text")},
+			want:    []dbIndexKeyModel{key("category", "1"), key("title", "text"), key("body", "text")},
+		},
+		{
+			name:    "import uses weights order",
+			key:     textSpec,
+			weights: weights,
+			want:    []dbIndexKeyModel{key("category", "1"), key("body", "text"), key("title", "text")},
+		},
+		{
+			name:    "declared fields that no longer match are replaced",
+			key:     textSpec,
+			weights: weights,
+			prior:   []dbIndexKeyModel{key("category", "1"), key("summary", "text"), key("body", "text")},
+			want:    []dbIndexKeyModel{key("category", "1"), key("body", "text"), key("title", "text")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := indexKeysFromSpec(tc.key, tc.weights, tc.prior); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIndexWeightsFromSpec(t *testing.T) {
+	weights := bson.D{{Key: "body", Value: int32(1)}, {Key: "title", Value: int32(10)}}
+
+	got := indexWeightsFromSpec(weights, types.MapNull(types.Int64Type))
+	want := types.MapValueMust(types.Int64Type, map[string]attr.Value{"title": types.Int64Value(10)})
+	if !got.Equal(want) {
+		t.Errorf("default weights dropped: got %v, want %v", got, want)
+	}
+
+	declared := types.MapValueMust(types.Int64Type, map[string]attr.Value{"body": types.Int64Value(1)})
+	got = indexWeightsFromSpec(weights, declared)
+	want = types.MapValueMust(types.Int64Type, map[string]attr.Value{"body": types.Int64Value(1), "title": types.Int64Value(10)})
+	if !got.Equal(want) {
+		t.Errorf("declared default weight kept: got %v, want %v", got, want)
+	}
+
+	if got := indexWeightsFromSpec(nil, types.MapNull(types.Int64Type)); !got.IsNull() {
+		t.Errorf("non-text index: got %v, want null", got)
+	}
+}
+
+func TestAccMongoDBIndex_Geo(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexGeo(databaseName, collectionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists("mongodb_db_index.flat"),
+					resource.TestCheckResourceAttr("mongodb_db_index.flat", "keys.0.value", "2d"),
+					resource.TestCheckResourceAttr("mongodb_db_index.flat", "bits", "20"),
+					resource.TestCheckResourceAttr("mongodb_db_index.flat", "min", "-1000"),
+					resource.TestCheckResourceAttr("mongodb_db_index.flat", "max", "1000"),
+					resource.TestCheckNoResourceAttr("mongodb_db_index.flat", "sphere_index_version"),
+					testAccCheckMongoDBIndexExists("mongodb_db_index.sphere"),
+					resource.TestCheckResourceAttr("mongodb_db_index.sphere", "keys.0.value", "2dsphere"),
+					resource.TestCheckResourceAttr("mongodb_db_index.sphere", "sphere_index_version", "2"),
+					resource.TestCheckNoResourceAttr("mongodb_db_index.sphere", "bits"),
+				),
+			},
+			{
+				ResourceName:            "mongodb_db_index.flat",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexGeo(dbName, collectionName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "flat" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = "grid"
+  keys {
+    field = "grid_position"
+    value = "2d"
+  }
+  bits = 20
+  min  = -1000
+  max  = 1000
+}
+
+resource "mongodb_db_index" "sphere" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = "location"
+  keys {
+    field = "location"
+    value = "2dsphere"
+  }
+  sphere_index_version = 2
+}
+`, dbName, collectionName)
+}
+
+func TestAccMongoDBIndex_Wildcard(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-idx")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMongoDBIndexWildcard(databaseName, collectionName, indexName, "payload.$**"),
+				ExpectError: regexp.MustCompile(`wildcard_projection requires a \$\*\* key`),
+			},
+			{
+				Config: testAccMongoDBIndexWildcard(databaseName, collectionName, indexName, "$**"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "keys.0.field", "$**"),
+					resource.TestCheckResourceAttr(resourceName, "wildcard_projection", `{"payload.secret":0}`),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexWildcard(dbName, collectionName, indexName, keyField string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = %[3]q
+  keys {
+    field = %[4]q
+    value = "1"
+  }
+  wildcard_projection = jsonencode({
+    "payload.secret" = 0
+  })
+}
+`, dbName, collectionName, indexName, keyField)
+}
+
+func TestSameWildcardProjection(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{`{"a":1,"b":1}`, `{"a":1,"b":1}`, true},
+		{`{"a":true}`, `{"a":1}`, true},
+		{`{"a":0}`, `{"a":false}`, true},
+		{`{"a":1}`, `{"a":0}`, false},
+		{`{"a":1,"b":1}`, `{"b":1,"a":1}`, false},
+		{`{"a":1}`, ``, false},
+		{``, ``, true},
+	}
+	for _, tc := range cases {
+		if got := sameWildcardProjection(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameWildcardProjection(%q, %q) = %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+// TestAccMongoDBIndex_WaitForBuild builds an index in wait mode, which watches
+// the build through $currentOp instead of applying timeout.
+func TestAccMongoDBIndex_WaitForBuild(t *testing.T) {
+	var indexName = acctest.RandomWithPrefix("tf-acc-idx")
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexWaitForBuild(databaseName, collectionName, indexName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_build", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout", "wait_for_build"},
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexWaitForBuild(dbName, collectionName, indexName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on = [mongodb_db_collection.test]
+  db         = %[1]q
+  collection = %[2]q
+  name       = %[3]q
+  keys {
+    field = "created_at"
+    value = "-1"
+  }
+  wait_for_build = true
+}
+`, dbName, collectionName, indexName)
+}
+
+func TestDefaultIndexName(t *testing.T) {
+	cases := []struct {
+		keys bson.D
+		want string
+	}{
+		{bson.D{{Key: "a", Value: 1}}, "a_1"},
+		{bson.D{{Key: "a", Value: 1}, {Key: "b", Value: -1}}, "a_1_b_-1"},
+		{bson.D{{Key: "loc", Value: "2dsphere"}}, "loc_2dsphere"},
+		{bson.D{{Key: "title", Value: "text"}, {Key: "body", Value: "text"}}, "title_text_body_text"},
+	}
+	for _, tc := range cases {
+		if got := defaultIndexName(tc.keys); got != tc.want {
+			t.Errorf("defaultIndexName(%v) = %q, want %q", tc.keys, got, tc.want)
+		}
+	}
+}
+
+func TestIsIndexBuildInProgress(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no error", nil, false},
+		{"build in progress", mongo.CommandError{Code: 276, Name: "IndexBuildAlreadyInProgress"}, true},
+		{"wrapped", fmt.Errorf("creating: %w", mongo.CommandError{Code: 276}), true},
+		{"options conflict", mongo.CommandError{Code: 85, Name: "IndexOptionsConflict"}, false},
+	}
+	for _, tc := range cases {
+		if got := isIndexBuildInProgress(tc.err); got != tc.want {
+			t.Errorf("%s: isIndexBuildInProgress = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestAccMongoDBIndex_StorageEngine creates a collection and an index with
+// WiredTiger options, in both accepted forms, and checks that changing the
+// index's options rebuilds it.
+func TestAccMongoDBIndex_StorageEngine(t *testing.T) {
+	var collectionName = acctest.RandomWithPrefix("tf-acc-coll")
+	var databaseName = acctest.RandomWithPrefix("tf-acc-db")
+	resourceName := "mongodb_db_index.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBIndexStorageEngine(databaseName, collectionName, `jsonencode({ wiredTiger = { configString = "prefix_compression=false" } })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_db_collection.test", "storage_engine", "block_compressor=zstd"),
+					testAccCheckMongoDBIndexExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "storage_engine", `{"wiredTiger":{"configString":"prefix_compression=false"}}`),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+			{
+				Config: testAccMongoDBIndexStorageEngine(databaseName, collectionName, `"prefix_compression=true"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_db_collection.test", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "storage_engine", "prefix_compression=true"),
+			},
+		},
+	})
+}
+
+func testAccMongoDBIndexStorageEngine(dbName, collectionName, indexStorageEngine string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_collection" "test" {
+  db                  = %[1]q
+  name                = %[2]q
+  deletion_protection = false
+  storage_engine      = "block_compressor=zstd"
+}
+
+resource "mongodb_db_index" "test" {
+  depends_on     = [mongodb_db_collection.test]
+  db             = %[1]q
+  collection     = %[2]q
+  name           = "sku"
+  storage_engine = %[3]s
+  keys {
+    field = "sku"
+    value = "1"
+  }
+}
+`, dbName, collectionName, indexStorageEngine)
+}
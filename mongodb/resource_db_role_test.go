@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -96,8 +97,8 @@ func TestAccMongoDBRole_MultiplePrivileges(t *testing.T) {
 	})
 }
 
-// TestAccMongoDBRole_Update exercises the role update path (which drops and
-// recreates the role): it changes the privilege set and verifies the new set.
+// TestAccMongoDBRole_Update exercises the in-place role update path
+// (updateRole): it changes the privilege set and verifies the new set.
 func TestAccMongoDBRole_Update(t *testing.T) {
 	roleName := acctest.RandomWithPrefix("tf-acc-role")
 	dbName := acctest.RandomWithPrefix("tf-acc-db")
@@ -128,6 +129,56 @@ func TestAccMongoDBRole_Update(t *testing.T) {
 	})
 }
 
+// TestAccMongoDBRole_UpdateKeepsMembership changes the privileges of a role that
+// is granted to a user and verifies the user still holds the role afterwards
+// (the old drop-and-recreate update silently revoked it).
+func TestAccMongoDBRole_UpdateKeepsMembership(t *testing.T) {
+	roleName := acctest.RandomWithPrefix("tf-acc-role")
+	userName := acctest.RandomWithPrefix("tf-acc-user")
+	dbName := acctest.RandomWithPrefix("tf-acc-db")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBRoleWithMember(dbName, roleName, userName, `["find"]`),
+				Check:  testAccCheckMongoDBUserHasRole(dbName, userName, roleName),
+			},
+			{
+				Config: testAccMongoDBRoleWithMember(dbName, roleName, userName, `["find", "insert"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_db_role.test", "privilege.#", "1"),
+					testAccCheckMongoDBUserHasRole(dbName, userName, roleName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMongoDBUserHasRole(dbName, userName, roleName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := MongoClientInit(testAccMongoConfig())
+		if err != nil {
+			return fmt.Errorf("error connecting to database: %s", err)
+		}
+		result, err := getUser(client, userName, dbName)
+		if err != nil {
+			return fmt.Errorf("error getting user: %s", err)
+		}
+		if len(result.Users) == 0 {
+			return fmt.Errorf("user not found: %s", userName)
+		}
+		for _, role := range result.Users[0].Roles {
+			if role.Role == roleName && role.Db == dbName {
+				return nil
+			}
+		}
+		return fmt.Errorf("user %s no longer holds role %s", userName, roleName)
+	}
+}
+
 // TestAccMongoDBRole_authenticationRestrictions creates a role with a
 // client_source authentication restriction and verifies it applies with a
 // stable (no-diff) plan.
@@ -303,3 +354,102 @@ resource "mongodb_db_role" "test" {
 }
 `, dbName, roleName, dbName, dbName)
 }
+
+func testAccMongoDBRoleWithMember(dbName, roleName, userName, actions string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_role" "test" {
+  database = %[1]q
+  name     = %[2]q
+
+  privilege {
+    db         = %[1]q
+    collection = "test_collection"
+    actions    = %[4]s
+  }
+}
+
+resource "mongodb_db_user" "test" {
+  auth_database = %[1]q
+  name          = %[3]q
+  password      = "tf-acc-password"
+
+  role {
+    db   = %[1]q
+    role = mongodb_db_role.test.name
+  }
+}
+`, dbName, roleName, userName, actions)
+}
+
+// TestAccMongoDBRole_ClusterPrivileges covers the cluster and anyResource
+// privilege forms. Both are only valid on roles defined in admin.
+func TestAccMongoDBRole_ClusterPrivileges(t *testing.T) {
+	var roleName = acctest.RandomWithPrefix("tf-acc-role")
+	resourceName := "mongodb_db_role.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMongoDBRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMongoDBRoleClusterFalse(roleName),
+				ExpectError: regexp.MustCompile(`cluster = false is not supported`),
+			},
+			{
+				Config: testAccMongoDBRoleClusterPrivileges(roleName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBRoleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "privilege.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "privilege.*", map[string]string{
+						"cluster":   "true",
+						"actions.0": "serverStatus",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "privilege.*", map[string]string{
+						"any_resource": "true",
+						"actions.0":    "find",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMongoDBRoleClusterFalse(roleName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_role" "test" {
+  database = "admin"
+  name     = %q
+
+  privilege {
+    db      = "admin"
+    cluster = false
+    actions = ["find"]
+  }
+}
+`, roleName)
+}
+
+func testAccMongoDBRoleClusterPrivileges(roleName string) string {
+	return fmt.Sprintf(`
+resource "mongodb_db_role" "test" {
+  database = "admin"
+  name     = %q
+
+  privilege {
+    cluster = true
+    actions = ["serverStatus"]
+  }
+
+  privilege {
+    any_resource = true
+    actions      = ["find"]
+  }
+}
+`, roleName)
+}